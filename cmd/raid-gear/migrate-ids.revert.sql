@@ -0,0 +1,7 @@
+-- Moves the raid gear back from entry + 23000000 to entry + 20000000 for an older build, undoes migrate-ids.sql
+UPDATE acore_world.item_template SET entry = entry - 3000000 WHERE entry IN (23016821, 23016826, 23016852, 23017073, 23017074, 23017077, 23018823, 23018832, 23018842, 23019143);
+UPDATE acore_world.npc_vendor SET item = item - 3000000 WHERE item IN (23016821, 23016826, 23016852, 23017073, 23017074, 23017077, 23018823, 23018832, 23018842, 23019143);
+UPDATE acore_world.creature_loot_template SET Item = Item - 3000000 WHERE Item IN (23016821, 23016826, 23016852, 23017073, 23017074, 23017077, 23018823, 23018832, 23018842, 23019143);
+UPDATE acore_world.gameobject_loot_template SET Item = Item - 3000000 WHERE Item IN (23016821, 23016826, 23016852, 23017073, 23017074, 23017077, 23018823, 23018832, 23018842, 23019143);
+UPDATE acore_world.reference_loot_template SET Item = Item - 3000000 WHERE Item IN (23016821, 23016826, 23016852, 23017073, 23017074, 23017077, 23018823, 23018832, 23018842, 23019143);
+UPDATE acore_characters.item_instance SET itemEntry = itemEntry - 3000000 WHERE itemEntry IN (23016821, 23016826, 23016852, 23017073, 23017074, 23017077, 23018823, 23018832, 23018842, 23019143);
@@ -0,0 +1,12 @@
+-- Moves the raid gear an older gear.manual.sql loaded at entry + 20000000, the dungeon mythic item range,
+-- to entry + 23000000 where raid-gear writes it now. Vendor, loot and character items follow the entry.
+-- Undo it with migrate-ids.revert.sql.
+--
+-- Drop the raid-gear rows already at the new entries first, from a newer run, or the move stops on a duplicate entry.
+DELETE FROM acore_world.item_template WHERE entry IN (23016821, 23016826, 23016852, 23017073, 23017074, 23017077, 23018823, 23018832, 23018842, 23019143);
+UPDATE acore_world.item_template SET entry = entry + 3000000 WHERE entry IN (20016821, 20016826, 20016852, 20017073, 20017074, 20017077, 20018823, 20018832, 20018842, 20019143);
+UPDATE acore_world.npc_vendor SET item = item + 3000000 WHERE item IN (20016821, 20016826, 20016852, 20017073, 20017074, 20017077, 20018823, 20018832, 20018842, 20019143);
+UPDATE acore_world.creature_loot_template SET Item = Item + 3000000 WHERE Item IN (20016821, 20016826, 20016852, 20017073, 20017074, 20017077, 20018823, 20018832, 20018842, 20019143);
+UPDATE acore_world.gameobject_loot_template SET Item = Item + 3000000 WHERE Item IN (20016821, 20016826, 20016852, 20017073, 20017074, 20017077, 20018823, 20018832, 20018842, 20019143);
+UPDATE acore_world.reference_loot_template SET Item = Item + 3000000 WHERE Item IN (20016821, 20016826, 20016852, 20017073, 20017074, 20017077, 20018823, 20018832, 20018842, 20019143);
+UPDATE acore_characters.item_instance SET itemEntry = itemEntry + 3000000 WHERE itemEntry IN (20016821, 20016826, 20016852, 20017073, 20017074, 20017077, 20018823, 20018832, 20018842, 20019143);
@@ -2,14 +2,14 @@ module github.com/araxiaonline/endgame-item-generator
 
 go 1.22.4
 
-require github.com/go-sql-driver/mysql v1.8.1
-
 require (
-	filippo.io/edwards25519 v1.1.0 // indirect
-	github.com/gocarina/gocsv v0.0.0-20240520201108-78e41c74b4b1 // indirect
-	github.com/jmoiron/sqlx v1.4.0 // indirect
-	github.com/joho/godotenv v1.5.1 // indirect
-	github.com/mattn/go-sqlite3 v1.14.22 // indirect
-	github.com/thoas/go-funk v0.9.3 // indirect
-	golang.org/x/exp v0.0.0-20240808152545-0cdaa3abc0fa // indirect
+	github.com/go-sql-driver/mysql v1.8.1
+	github.com/gocarina/gocsv v0.0.0-20240520201108-78e41c74b4b1
+	github.com/jmoiron/sqlx v1.4.0
+	github.com/joho/godotenv v1.5.1
+	github.com/mattn/go-sqlite3 v1.14.22
+	github.com/thoas/go-funk v0.9.3
+	golang.org/x/exp v0.0.0-20240808152545-0cdaa3abc0fa
 )
+
+require filippo.io/edwards25519 v1.1.0 // indirect
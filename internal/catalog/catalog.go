@@ -0,0 +1,290 @@
+package catalog
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/export"
+)
+
+//go:embed templates/*.html
+var templates embed.FS
+
+var pages = template.Must(template.New("catalog").Funcs(template.FuncMap{"itemPage": ItemPage, "anchor": anchor}).ParseFS(templates, "templates/*.html"))
+
+// World is where the original items and the spell texts are read from, any store.Store fits
+type World interface {
+	GetItem(entry int) (mysql.DbItem, error)
+	GetSpell(id int) (mysql.DbSpell, error)
+}
+
+// Location is where a source item drops, a boss of 0 is a dungeon drop not tied to one boss
+type Location struct {
+	Dungeon string
+	Boss    string
+}
+
+// Locator finds where a source item drops, false when it is not a dungeon item
+type Locator func(entry int) (Location, bool)
+
+// Item is one generated item next to the item it was generated from
+type Item struct {
+	Record    export.Record
+	Generated Tooltip
+	Original  *Tooltip // nil when the source item is not in the world database
+	Location  Location
+}
+
+type Boss struct {
+	Name  string
+	Items []*Item
+}
+
+type Dungeon struct {
+	Name   string
+	Bosses []*Boss
+}
+
+// Difficulty is every generated item of one difficulty or emblem tier grouped by dungeon and boss
+type Difficulty struct {
+	Key      string
+	Name     string
+	Count    int
+	Dungeons []*Dungeon
+}
+
+type Catalog struct {
+	Title        string
+	Documents    []*export.Document
+	Difficulties []*Difficulty
+	Items        []*Item
+}
+
+var difficultyNames = map[int]string{3: "Mythic", 4: "Legendary", 5: "Ascendant"}
+
+const (
+	otherDungeon = "Other items"
+	dungeonDrops = "Dungeon drops"
+)
+
+// Build reads the originals and spell texts for every item in the documents and groups them. An item in
+// more than one document is shown as the last one.
+func Build(title string, documents []*export.Document, world World, locate Locator) (*Catalog, error) {
+	catalog := &Catalog{Title: title, Documents: documents}
+	spells := map[int]mysql.DbSpell{}
+	getSpell := func(id int) (mysql.DbSpell, error) {
+		if spell, ok := spells[id]; ok {
+			return spell, nil
+		}
+		spell, err := world.GetSpell(id)
+		if err != nil {
+			return spell, err
+		}
+		spells[id] = spell
+		return spell, nil
+	}
+
+	byEntry := map[int]*Item{}
+	for _, document := range documents {
+		for _, record := range document.Items {
+			generated, err := generatedSpells(record, getSpell)
+			if err != nil {
+				return nil, err
+			}
+			item := &Item{Record: record, Generated: NewTooltip(record.Item, generated)}
+
+			if source, err := world.GetItem(record.SourceEntry); err == nil {
+				original, err := originalSpells(source, getSpell)
+				if err != nil {
+					return nil, err
+				}
+				tip := NewTooltip(source, original)
+				item.Original = &tip
+			}
+
+			item.Location = Location{Dungeon: otherDungeon}
+			if location, ok := locate(record.SourceEntry); ok {
+				item.Location = location
+			}
+			if _, ok := byEntry[record.Entry]; !ok {
+				catalog.Items = append(catalog.Items, item)
+			}
+			byEntry[record.Entry] = item
+		}
+	}
+	for i, item := range catalog.Items {
+		catalog.Items[i] = byEntry[item.Record.Entry]
+	}
+
+	catalog.group()
+	return catalog, nil
+}
+
+// the generated spells keep the trigger of the source spell they were scaled from
+func generatedSpells(record export.Record, getSpell func(int) (mysql.DbSpell, error)) ([]SpellLine, error) {
+	lines := []SpellLine{}
+	for _, spell := range record.Spells {
+		source, err := getSpell(spell.SourceId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read spell %d of item %d: %w", spell.SourceId, record.Entry, err)
+		}
+		lines = append(lines, SpellLine{Trigger: trigger(record.Item, spell.SourceId, spell.Id), Text: Describe(source, spell.BasePoints)})
+	}
+	return lines, nil
+}
+
+func originalSpells(item mysql.DbItem, getSpell func(int) (mysql.DbSpell, error)) ([]SpellLine, error) {
+	lines := []SpellLine{}
+	for _, slot := range []struct{ id, trigger *int }{{item.SpellId1, item.SpellTrigger1}, {item.SpellId2, item.SpellTrigger2}, {item.SpellId3, item.SpellTrigger3}} {
+		if value(slot.id) <= 0 {
+			continue
+		}
+		spell, err := getSpell(*slot.id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read spell %d of item %d: %w", *slot.id, item.Entry, err)
+		}
+		basePoints := [3]int{spell.EffectBasePoints1, spell.EffectBasePoints2, spell.EffectBasePoints3}
+		lines = append(lines, SpellLine{Trigger: value(slot.trigger), Text: Describe(spell, basePoints)})
+	}
+	return lines, nil
+}
+
+// trigger of the item spell slot holding the spell, under its source or its new id
+func trigger(item mysql.DbItem, ids ...int) int {
+	for _, slot := range []struct{ id, trigger *int }{{item.SpellId1, item.SpellTrigger1}, {item.SpellId2, item.SpellTrigger2}, {item.SpellId3, item.SpellTrigger3}} {
+		for _, id := range ids {
+			if value(slot.id) == id {
+				return value(slot.trigger)
+			}
+		}
+	}
+	return 1
+}
+
+func difficultyOf(record export.Record) (string, string) {
+	if record.Tier > 0 {
+		return fmt.Sprintf("tier-%d", record.Tier), fmt.Sprintf("Emblem Tier %d", record.Tier)
+	}
+	name, ok := difficultyNames[record.Difficulty]
+	if !ok {
+		name = fmt.Sprintf("Difficulty %d", record.Difficulty)
+	}
+	return fmt.Sprintf("difficulty-%d", record.Difficulty), name
+}
+
+func (c *Catalog) group() {
+	difficulties := map[string]*Difficulty{}
+	dungeons := map[string]*Dungeon{}
+	bosses := map[string]*Boss{}
+
+	for _, item := range c.Items {
+		key, name := difficultyOf(item.Record)
+		difficulty, ok := difficulties[key]
+		if !ok {
+			difficulty = &Difficulty{Key: key, Name: name}
+			difficulties[key] = difficulty
+			c.Difficulties = append(c.Difficulties, difficulty)
+		}
+		difficulty.Count++
+
+		dungeonKey := key + "/" + item.Location.Dungeon
+		dungeon, ok := dungeons[dungeonKey]
+		if !ok {
+			dungeon = &Dungeon{Name: item.Location.Dungeon}
+			dungeons[dungeonKey] = dungeon
+			difficulty.Dungeons = append(difficulty.Dungeons, dungeon)
+		}
+
+		bossName := item.Location.Boss
+		if bossName == "" {
+			bossName = dungeonDrops
+		}
+		bossKey := dungeonKey + "/" + bossName
+		boss, ok := bosses[bossKey]
+		if !ok {
+			boss = &Boss{Name: bossName}
+			bosses[bossKey] = boss
+			dungeon.Bosses = append(dungeon.Bosses, boss)
+		}
+		boss.Items = append(boss.Items, item)
+	}
+
+	// named groups in alphabetical order, the catch alls last
+	sort.Slice(c.Difficulties, func(i, j int) bool { return c.Difficulties[i].Key < c.Difficulties[j].Key })
+	for _, difficulty := range c.Difficulties {
+		sort.Slice(difficulty.Dungeons, func(i, j int) bool {
+			return lessLast(difficulty.Dungeons[i].Name, difficulty.Dungeons[j].Name, otherDungeon)
+		})
+		for _, dungeon := range difficulty.Dungeons {
+			sort.Slice(dungeon.Bosses, func(i, j int) bool {
+				return lessLast(dungeon.Bosses[i].Name, dungeon.Bosses[j].Name, dungeonDrops)
+			})
+			for _, boss := range dungeon.Bosses {
+				sort.Slice(boss.Items, func(i, j int) bool { return boss.Items[i].Record.Entry < boss.Items[j].Record.Entry })
+			}
+		}
+	}
+}
+
+func lessLast(a, b, last string) bool {
+	if a == last || b == last {
+		return b == last && a != last
+	}
+	return a < b
+}
+
+// Write renders the catalog into dir: index.html, a page per difficulty and a page per item with its original.
+// Every page carries its own styles so the site works from disk with nothing else.
+func (c *Catalog) Write(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := render(filepath.Join(dir, "index.html"), "index", c); err != nil {
+		return err
+	}
+	for _, difficulty := range c.Difficulties {
+		data := struct {
+			Catalog    *Catalog
+			Difficulty *Difficulty
+		}{c, difficulty}
+		if err := render(filepath.Join(dir, difficulty.Key+".html"), "difficulty", data); err != nil {
+			return err
+		}
+	}
+	for _, item := range c.Items {
+		if err := render(filepath.Join(dir, ItemPage(item.Record.Entry)), "item", item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func ItemPage(entry int) string {
+	return fmt.Sprintf("item-%d.html", entry)
+}
+
+// anchor is name as an html id, Blackrock Depths is blackrock-depths
+func anchor(name string) string {
+	return strings.Trim(nonWord.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+var nonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+func render(path, name string, data interface{}) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := pages.ExecuteTemplate(file, name, data); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to render %s: %w", path, err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,117 @@
+package catalog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/export"
+)
+
+func intPtr(v int) *int           { return &v }
+func floatPtr(v float64) *float64 { return &v }
+
+func sword(entry int, name string, stamina int) mysql.DbItem {
+	return mysql.DbItem{
+		Entry: entry, Name: name, Quality: intPtr(4), ItemLevel: intPtr(300), Class: intPtr(2), Subclass: intPtr(7),
+		InventoryType: intPtr(13), RequiredLevel: intPtr(80),
+		MinDmg1: floatPtr(300), MaxDmg1: floatPtr(500), Delay: floatPtr(2600),
+		StatType1: intPtr(7), StatValue1: intPtr(stamina), StatType2: intPtr(36), StatValue2: intPtr(40),
+		SpellId1: intPtr(21919), SpellTrigger1: intPtr(2),
+	}
+}
+
+type testWorld struct{}
+
+func (testWorld) GetItem(entry int) (mysql.DbItem, error) {
+	if entry != 100 {
+		return mysql.DbItem{}, errors.New("no item")
+	}
+	return sword(100, "Sword", 20), nil
+}
+
+func (testWorld) GetSpell(id int) (mysql.DbSpell, error) {
+	return mysql.DbSpell{ID: id, Name: "Chilled", Description: "Slows the target by $s1% for 5 sec.", EffectBasePoints1: -11}, nil
+}
+
+func TestNewTooltip(t *testing.T) {
+	tip := NewTooltip(sword(100, "Sword", 20), []SpellLine{{Trigger: 2, Text: "Slows the target."}})
+
+	expected := []string{tip.Slot, tip.Type, tip.Damage, tip.Speed, tip.Dps}
+	for i, line := range []string{"One-Hand", "Sword", "300 - 500 Damage", "Speed 2.60", "(153.8 damage per second)"} {
+		if expected[i] != line {
+			t.Errorf("expected %q, got %q", line, expected[i])
+		}
+	}
+	if len(tip.Stats) != 1 || tip.Stats[0] != "+20 Stamina" {
+		t.Errorf("unexpected stats %v", tip.Stats)
+	}
+	if len(tip.Equips) != 2 || tip.Equips[0] != "Equip: Increases haste rating by 40." || tip.Equips[1] != "Chance on hit: Slows the target." {
+		t.Errorf("unexpected equips %v", tip.Equips)
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	spell := mysql.DbSpell{Name: "Fire Blast", Description: "Deals $s1 Fire damage and $s2 more.", EffectDieSides1: 11}
+	if text := Describe(spell, [3]int{99, 4, 0}); text != "Deals 100 to 110 Fire damage and 5 more." {
+		t.Errorf("unexpected description %q", text)
+	}
+	if text := Describe(mysql.DbSpell{Name: "Chilled"}, [3]int{}); text != "Chilled" {
+		t.Errorf("expected the name without a description, got %q", text)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	document := &export.Document{Tool: "item-gen", Items: []export.Record{
+		{Entry: 20000100, SourceEntry: 100, Difficulty: 3, Item: sword(20000100, "Mythic Sword", 60),
+			Spells: []export.Spell{{SourceId: 21919, Id: 30021919, BasePoints: [3]int{-21}}}},
+		{Entry: 20000200, SourceEntry: 200, Difficulty: 3, Item: sword(20000200, "Mythic Axe", 50)},
+		{Entry: 21000100, SourceEntry: 100, Difficulty: 4, Item: sword(21000100, "Legendary Sword", 80)},
+	}}
+	locate := func(entry int) (Location, bool) {
+		return Location{Dungeon: "Utgarde Keep", Boss: "Ingvar the Plunderer"}, entry == 100
+	}
+
+	site, err := Build("Test", []*export.Document{document}, testWorld{}, locate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(site.Difficulties) != 2 || site.Difficulties[0].Name != "Mythic" || site.Difficulties[0].Count != 2 {
+		t.Fatalf("unexpected difficulties %+v", site.Difficulties)
+	}
+	if dungeons := site.Difficulties[0].Dungeons; len(dungeons) != 2 || dungeons[0].Name != "Utgarde Keep" || dungeons[1].Name != otherDungeon {
+		t.Errorf("expected the dungeon before the catch all, got %+v", dungeons)
+	}
+
+	dir := t.TempDir()
+	if err := site.Write(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	page := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(string(data), "http") {
+			t.Errorf("%s links to something outside the site", name)
+		}
+		return string(data)
+	}
+	page("index.html")
+	if mythic := page("difficulty-3.html"); !strings.Contains(mythic, "Ingvar the Plunderer") || !strings.Contains(mythic, "item-20000100.html") {
+		t.Errorf("expected the mythic page to group by boss and link the items:\n%s", mythic)
+	}
+	item := page("item-20000100.html")
+	for _, text := range []string{"Mythic Sword", "Sword", "60 Stamina", "20 Stamina", "Slows the target by 20%", "Slows the target by 10%"} {
+		if !strings.Contains(item, text) {
+			t.Errorf("expected %q on the item page", text)
+		}
+	}
+	if missing := page("item-20000200.html"); !strings.Contains(missing, "not in the world database") {
+		t.Error("expected the item without an original to say so")
+	}
+}
@@ -0,0 +1,161 @@
+package catalog
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/config"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/items"
+)
+
+// Tooltip is the lines the game shows when hovering an item, in the order it shows them
+type Tooltip struct {
+	Entry         int
+	Name          string
+	Quality       int
+	ItemLevel     int
+	Slot          string
+	Type          string // armor material or weapon type
+	Armor         int
+	Damage        string
+	Speed         string
+	Dps           string
+	Stats         []string // the primary stats shown as +N Stat
+	Resistances   []string
+	RequiredLevel int
+	Equips        []string // ratings and spells, already prefixed with Equip:, Use: or Chance on hit:
+}
+
+// SpellLine is a spell on an item and the text the tooltip shows for it
+type SpellLine struct {
+	Trigger int
+	Text    string
+}
+
+var slotNames = map[int]string{
+	1: "Head", 2: "Neck", 3: "Shoulder", 4: "Shirt", 5: "Chest", 6: "Waist", 7: "Legs", 8: "Feet", 9: "Wrist",
+	10: "Hands", 11: "Finger", 12: "Trinket", 13: "One-Hand", 14: "Off Hand", 15: "Ranged", 16: "Back",
+	17: "Two-Hand", 18: "Bag", 19: "Tabard", 20: "Chest", 21: "Main Hand", 22: "Off Hand", 23: "Held In Off-hand",
+	24: "Projectile", 25: "Thrown", 26: "Ranged", 28: "Relic",
+}
+
+var armorTypes = map[int]string{1: "Cloth", 2: "Leather", 3: "Mail", 4: "Plate", 6: "Shield", 7: "Libram", 8: "Idol", 9: "Totem", 10: "Sigil"}
+
+var weaponTypes = map[int]string{
+	0: "Axe", 1: "Axe", 2: "Bow", 3: "Gun", 4: "Mace", 5: "Mace", 6: "Polearm", 7: "Sword", 8: "Sword",
+	10: "Staff", 13: "Fist Weapon", 15: "Dagger", 16: "Thrown", 18: "Crossbow", 19: "Wand", 20: "Fishing Pole",
+}
+
+// stats the game shows as +N Stat, every other stat is an Equip: line
+var primaryStats = map[int]bool{0: true, 1: true, 3: true, 4: true, 5: true, 6: true, 7: true}
+
+var triggerPrefixes = map[int]string{0: "Use: ", 1: "Equip: ", 2: "Chance on hit: ", 4: "Use: ", 5: "Use: ", 6: "Use: "}
+
+func NewTooltip(item mysql.DbItem, spells []SpellLine) Tooltip {
+	tip := Tooltip{
+		Entry:         item.Entry,
+		Name:          item.Name,
+		Quality:       value(item.Quality),
+		ItemLevel:     value(item.ItemLevel),
+		Slot:          slotNames[value(item.InventoryType)],
+		Armor:         value(item.Armor),
+		RequiredLevel: value(item.RequiredLevel),
+	}
+
+	full := items.ItemFromDbItem(item)
+	switch value(item.Class) {
+	case 2:
+		tip.Type = weaponTypes[value(item.Subclass)]
+		if dps, err := full.GetDPS(); err == nil && dps > 0 {
+			tip.Damage = fmt.Sprintf("%.0f - %.0f Damage", *item.MinDmg1, *item.MaxDmg1)
+			tip.Speed = fmt.Sprintf("Speed %.2f", *item.Delay/1000)
+			tip.Dps = fmt.Sprintf("(%.1f damage per second)", dps)
+		}
+	case 4:
+		tip.Type = armorTypes[value(item.Subclass)]
+	}
+
+	for i := 1; i <= 10; i++ {
+		statType, _ := full.GetField(fmt.Sprintf("StatType%d", i))
+		statValue, _ := full.GetField(fmt.Sprintf("StatValue%d", i))
+		if statValue == 0 {
+			continue
+		}
+		name := statName(statType)
+		if primaryStats[statType] {
+			tip.Stats = append(tip.Stats, fmt.Sprintf("%+d %s", statValue, title(name)))
+		} else {
+			tip.Equips = append(tip.Equips, fmt.Sprintf("Equip: Increases %s by %d.", name, statValue))
+		}
+	}
+
+	for _, res := range []struct {
+		name  string
+		value *int
+	}{{"Holy", item.HolyRes}, {"Fire", item.FireRes}, {"Nature", item.NatureRes}, {"Frost", item.FrostRes}, {"Shadow", item.ShadowRes}, {"Arcane", item.ArcaneRes}} {
+		if value(res.value) > 0 {
+			tip.Resistances = append(tip.Resistances, fmt.Sprintf("+%d %s Resistance", *res.value, res.name))
+		}
+	}
+
+	for _, spell := range spells {
+		prefix, ok := triggerPrefixes[spell.Trigger]
+		if !ok {
+			prefix = "Equip: "
+		}
+		tip.Equips = append(tip.Equips, prefix+spell.Text)
+	}
+	return tip
+}
+
+// statName is the StatModifierNames name the way the game writes it, HASTE_RATING is haste rating
+func statName(statType int) string {
+	name, ok := config.StatModifierNames[statType]
+	if !ok {
+		return fmt.Sprintf("stat %d", statType)
+	}
+	return strings.ToLower(strings.ReplaceAll(name, "_", " "))
+}
+
+func title(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+var effectValue = regexp.MustCompile(`\$s([1-3])`)
+
+// Describe is the spell's description with its effect values filled in from basePoints, the name when it has none
+func Describe(spell mysql.DbSpell, basePoints [3]int) string {
+	if spell.Description == "" {
+		return spell.Name
+	}
+	dieSides := [3]int{spell.EffectDieSides1, spell.EffectDieSides2, spell.EffectDieSides3}
+	return effectValue.ReplaceAllStringFunc(spell.Description, func(match string) string {
+		i, _ := strconv.Atoi(match[2:])
+		low := basePoints[i-1] + 1
+		if dieSides[i-1] <= 1 {
+			return strconv.Itoa(abs(low))
+		}
+		return fmt.Sprintf("%d to %d", abs(low), abs(basePoints[i-1]+dieSides[i-1]))
+	})
+}
+
+func value(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,139 @@
+package catalog
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	itemcatalog "github.com/araxiaonline/endgame-item-generator/internal/catalog"
+	"github.com/araxiaonline/endgame-item-generator/internal/cli"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlite"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/store"
+	"github.com/araxiaonline/endgame-item-generator/internal/export"
+	"github.com/araxiaonline/endgame-item-generator/internal/logging"
+)
+
+var Command = cli.Command{
+	Name:    "catalog",
+	Summary: "render the items of -format json runs as a static site",
+	Run:     run,
+}
+
+// Renders the items of one or more -format json runs into a static site with a tooltip for every item,
+// grouped by difficulty, dungeon and boss. Each item links to a page with its original next to it.
+//
+//	item-gen catalog -out ./catalog mythic.json legendary.json ascendant.json
+func run(g *cli.Globals, args []string) error {
+	fs := g.FlagSet("catalog", "<json files>", "directory to write the site to", "catalog")
+	title := fs.String("title", "Generated Items", "title of the index page")
+	if err := g.Parse(fs, args, false); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		return errors.New("pass the json files written with -format json")
+	}
+
+	documents := []*export.Document{}
+	for _, path := range fs.Args() {
+		document, err := readDocument(path)
+		if err != nil {
+			return err
+		}
+		documents = append(documents, document)
+	}
+
+	worldDb, err := store.Open(g.Snapshot)
+	if err != nil {
+		return err
+	}
+	defer worldDb.Close()
+
+	// an empty -items-db skips the dungeon grouping
+	locate := func(entry int) (itemcatalog.Location, bool) { return itemcatalog.Location{}, false }
+	if g.ItemsDb != "" {
+		sqliteDb, err := sqlite.Connect(g.ItemsDb)
+		if err != nil {
+			return err
+		}
+		defer sqliteDb.Close()
+		locate, err = locator(sqliteDb, worldDb)
+		if err != nil {
+			return err
+		}
+	}
+
+	site, err := itemcatalog.Build(*title, documents, worldDb, locate)
+	if err != nil {
+		return err
+	}
+	if err := site.Write(g.Out); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %d items in %d difficulties to %s\n", len(site.Items), len(site.Difficulties), g.Out)
+	return nil
+}
+
+func readDocument(path string) (*export.Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	document := &export.Document{}
+	if err := json.Unmarshal(data, document); err != nil {
+		return nil, fmt.Errorf("%s is not a -format json document: %w", path, err)
+	}
+	return document, nil
+}
+
+// locator finds the dungeon and boss of a source item in dungeon_items, the names come from the world database
+func locator(sqliteDb *sqlite.SqlLite, worldDb store.Store) (itemcatalog.Locator, error) {
+	dungeons := map[int]string{}
+	list, err := worldDb.GetDungeons(-1)
+	if err != nil {
+		return nil, err
+	}
+	for _, dungeon := range list {
+		dungeons[dungeon.Id] = dungeon.Name
+	}
+
+	bosses := map[int]map[int]string{}
+	bossName := func(mapId, creatureId int) string {
+		if _, ok := bosses[mapId]; !ok {
+			bosses[mapId] = map[int]string{}
+			list, err := worldDb.GetBosses(mapId)
+			if err != nil {
+				slog.Warn("failed to get the bosses", "map", mapId, "err", err)
+			}
+			for _, boss := range list {
+				bosses[mapId][boss.Entry] = boss.Name
+			}
+		}
+		if name, ok := bosses[mapId][creatureId]; ok {
+			return name
+		}
+		return fmt.Sprintf("Creature %d", creatureId)
+	}
+
+	return func(entry int) (itemcatalog.Location, bool) {
+		item, err := sqliteDb.GetItemFromDungeon(entry)
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				slog.Warn("failed to look up the item in the dungeon items", logging.Entry, entry, "err", err)
+			}
+			return itemcatalog.Location{}, false
+		}
+
+		location := itemcatalog.Location{Dungeon: dungeons[item.MapId]}
+		if location.Dungeon == "" {
+			location.Dungeon = fmt.Sprintf("Map %d", item.MapId)
+		}
+		if item.CreatureId != 0 {
+			location.Boss = bossName(item.MapId, item.CreatureId)
+		}
+		return location, true
+	}, nil
+}
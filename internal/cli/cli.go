@@ -0,0 +1,220 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/logging"
+	"github.com/joho/godotenv"
+)
+
+// Name is what the binary is called in the help text
+const Name = "item-gen"
+
+// Command is one subcommand of item-gen
+type Command struct {
+	Name    string
+	Summary string // one line for the command list
+	// Run declares the command's flags on a FlagSet from Globals.FlagSet, parses args with Globals.Parse
+	// and does the work. The error is printed and item-gen exits with 1.
+	Run func(g *Globals, args []string) error
+}
+
+// Globals are the flags every command takes, before or after the command name
+type Globals struct {
+	Env       string
+	Snapshot  string
+	ItemsDb   string
+	Profile   string
+	Out       string
+	LogLevel  string
+	LogFormat string
+	LogFile   string
+	Debug     bool
+
+	stderr io.Writer
+	// set when the command wrote stdout to -out
+	stdout   *os.File
+	closeLog func() error
+}
+
+func NewGlobals() *Globals {
+	return &Globals{Env: ".env", ItemsDb: "data/items.db", LogLevel: "info", LogFormat: "text", stderr: os.Stderr}
+}
+
+var globalNames = map[string]bool{}
+
+// register adds the global flags to fs with the values parsed so far as defaults, out is what -out means
+// for the command
+func (g *Globals) register(fs *flag.FlagSet, out string) {
+	fs.StringVar(&g.Env, "env", g.Env, "file with the DB_HOST, DB_USER, DB_PASSWORD and DB_NAME settings, skipped when it does not exist")
+	fs.StringVar(&g.Snapshot, "snapshot", g.Snapshot, "SQLite world snapshot (see the snapshot command) to read instead of the MySQL DB_* connection")
+	fs.StringVar(&g.ItemsDb, "items-db", g.ItemsDb, "the dungeon_items database the crawl-dungeons command writes")
+	fs.StringVar(&g.Profile, "profile", g.Profile, "generation profile json with the modifiers and item level bonuses, defaults to the built in profile (see profiles/default.json)")
+	fs.StringVar(&g.Out, "out", g.Out, out)
+	fs.StringVar(&g.LogLevel, "log-level", g.LogLevel, fmt.Sprintf("lowest level logged, one of %s", strings.Join(logging.Levels, ", ")))
+	fs.StringVar(&g.LogFormat, "log-format", g.LogFormat, "log records as text or json")
+	fs.StringVar(&g.LogFile, "log-file", g.LogFile, "file the logs are appended to, defaults to stderr so they never mix with the output on stdout")
+	fs.BoolVar(&g.Debug, "debug", g.Debug, "verbose logging, the same as -log-level debug")
+	fs.VisitAll(func(f *flag.Flag) { globalNames[f.Name] = true })
+}
+
+// FlagSet is the flag set of a command with the global flags on it. out is what -out is for the command
+// and its default, with an empty out -out is a file that replaces stdout.
+func (g *Globals) FlagSet(name, usage, out, outDefault string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(g.stderr)
+	if out == "" {
+		out = "file the output is written to instead of stdout"
+	}
+	if g.Out == "" {
+		g.Out = outDefault
+	}
+	g.register(fs, out)
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintf(w, "Usage: %s\n\nFlags:\n", strings.TrimSpace(fmt.Sprintf("%s %s [flags] %s", Name, name, usage)))
+		printFlags(w, fs, func(f *flag.Flag) bool { return !globalNames[f.Name] })
+		fmt.Fprintf(w, "\nGlobal flags:\n")
+		printFlags(w, fs, func(f *flag.Flag) bool { return globalNames[f.Name] })
+	}
+	return fs
+}
+
+func printFlags(w io.Writer, fs *flag.FlagSet, keep func(f *flag.Flag) bool) {
+	names := flag.NewFlagSet("", flag.ContinueOnError)
+	names.SetOutput(w)
+	fs.VisitAll(func(f *flag.Flag) {
+		if keep(f) {
+			names.Var(f.Value, f.Name, f.Usage)
+		}
+	})
+	names.PrintDefaults()
+}
+
+// usageError is a bad flag, the flag package already printed it with the usage
+type usageError struct{ error }
+
+// Parse parses the command's flags, loads the env file, sets up the logs and, when -out is the
+// command's stdout, sends stdout to it
+func (g *Globals) Parse(fs *flag.FlagSet, args []string, stdout bool) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return usageError{err}
+	}
+
+	if err := godotenv.Load(g.Env); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", g.Env, err)
+	}
+
+	level := g.LogLevel
+	if g.Debug {
+		level = "debug"
+	}
+	closeLog, err := logging.Setup(level, g.LogFormat, g.LogFile)
+	if err != nil {
+		return err
+	}
+	g.closeLog = closeLog
+
+	if stdout && g.Out != "" {
+		file, err := os.Create(g.Out)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", g.Out, err)
+		}
+		g.stdout, os.Stdout = file, file
+	}
+	return nil
+}
+
+// Close closes the log file and the file that replaced stdout
+func (g *Globals) Close() error {
+	var errs []error
+	if g.stdout != nil {
+		errs = append(errs, g.stdout.Close())
+	}
+	if g.closeLog != nil {
+		errs = append(errs, g.closeLog())
+	}
+	return errors.Join(errs...)
+}
+
+// Main runs the command named by the first argument that is not a global flag and exits
+func Main(args []string, commands ...Command) {
+	os.Exit(Run(os.Stderr, args, commands...))
+}
+
+// Run is Main without the exit, it returns the exit code
+func Run(stderr io.Writer, args []string, commands ...Command) int {
+	byName := map[string]Command{}
+	for _, command := range commands {
+		byName[command.Name] = command
+	}
+
+	g := NewGlobals()
+	g.stderr = stderr
+	fs := flag.NewFlagSet(Name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	g.register(fs, "file the output is written to, what it is depends on the command")
+	fs.Usage = func() { usage(stderr, fs, commands) }
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if fs.NArg() == 0 {
+		usage(stderr, fs, commands)
+		return 2
+	}
+
+	name, rest := fs.Arg(0), fs.Args()[1:]
+	if name == "help" {
+		if len(rest) == 0 {
+			usage(stderr, fs, commands)
+			return 0
+		}
+		name, rest = rest[0], []string{"-h"}
+	}
+
+	command, ok := byName[name]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q, run %s help for the list\n", name, Name)
+		return 2
+	}
+
+	err := command.Run(g, rest)
+	if closeErr := g.Close(); err == nil {
+		err = closeErr
+	}
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.As(err, &usageError{}):
+		return 2
+	case err != nil:
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	return 0
+}
+
+func usage(w io.Writer, fs *flag.FlagSet, commands []Command) {
+	fmt.Fprintf(w, "Usage: %s [global flags] <command> [flags] [arguments]\n\nCommands:\n", Name)
+	sorted := append([]Command{}, commands...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	for _, command := range sorted {
+		fmt.Fprintf(w, "  %-16s %s\n", command.Name, command.Summary)
+	}
+	fmt.Fprintf(w, "\nRun %s help <command> for the flags of a command.\n\nGlobal flags:\n", Name)
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+}
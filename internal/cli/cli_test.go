@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// echo prints its -word and the snapshot it was given
+func echo(seen *Globals) Command {
+	return Command{Name: "echo", Summary: "print a word", Run: func(g *Globals, args []string) error {
+		fs := g.FlagSet("echo", "", "", "")
+		word := fs.String("word", "hello", "word to print")
+		if err := g.Parse(fs, args, true); err != nil {
+			return err
+		}
+		*seen = *g
+		if *word == "fail" {
+			return errors.New("echo failed")
+		}
+		fmt.Printf("%s %s\n", *word, g.Snapshot)
+		return nil
+	}}
+}
+
+func TestRun(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	stdout := os.Stdout
+	defer func() { os.Stdout = stdout }()
+	dir := t.TempDir()
+
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stderr string
+	}{
+		{"command list", []string{"help"}, 0, "echo             print a word"},
+		{"no command", []string{}, 2, "Usage: item-gen [global flags]"},
+		{"command help", []string{"help", "echo"}, 0, "Usage: item-gen echo [flags]"},
+		{"unknown command", []string{"bogus"}, 2, `unknown command "bogus"`},
+		{"unknown flag", []string{"echo", "-bogus"}, 2, "flag provided but not defined: -bogus"},
+		{"failed", []string{"echo", "-word", "fail", "-env", filepath.Join(dir, "missing.env")}, 1, "echo failed"},
+		{"bad log level", []string{"-log-level", "loud", "echo"}, 1, "unknown log level"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			var seen Globals
+			if code := Run(&stderr, test.args, echo(&seen)); code != test.code {
+				t.Errorf("expected exit %d, got %d: %s", test.code, code, stderr.String())
+			}
+			if !strings.Contains(stderr.String(), test.stderr) {
+				t.Errorf("expected %q in:\n%s", test.stderr, stderr.String())
+			}
+		})
+	}
+}
+
+func TestGlobalsBeforeAndAfter(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+	stdout := os.Stdout
+	defer func() { os.Stdout = stdout }()
+
+	out := filepath.Join(t.TempDir(), "out.txt")
+	var seen Globals
+	var stderr bytes.Buffer
+	args := []string{"-snapshot", "world.db", "-log-level", "error", "echo", "-word", "hi", "-out", out, "-items-db", "items.db"}
+	if code := Run(&stderr, args, echo(&seen)); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	if seen.Snapshot != "world.db" || seen.ItemsDb != "items.db" || seen.LogLevel != "error" || seen.Profile != "" {
+		t.Errorf("unexpected globals %+v", seen)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "hi world.db\n" {
+		t.Errorf("expected the output in -out, got %q", data)
+	}
+}
@@ -0,0 +1,142 @@
+package crawl
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/cli"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlite"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/store"
+	"github.com/araxiaonline/endgame-item-generator/internal/logging"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var Command = cli.Command{
+	Name:    "crawl-dungeons",
+	Summary: "crawl every dungeon's boss loot and drops into the dungeon_items database",
+	Run:     run,
+}
+
+func createTable(db *sql.DB) error {
+
+	droptable := `DROP TABLE IF EXISTS dungeon_items`
+	_, err := db.Exec(droptable)
+	if err != nil {
+		return err
+	}
+
+	createTable := `CREATE TABLE IF NOT EXISTS dungeon_items (
+		entry int unsigned NOT NULL DEFAULT '0',
+		mapId tinyint unsigned NOT NULL DEFAULT '0',
+		expansion tinyint unsigned NOT NULL DEFAULT '0',
+		dungeonLevel tinyint unsigned NOT NULL DEFAULT '0',
+		creatureId unsigned NULL DEFAULT NULL,		
+		Quality int unsigned NOT NULL DEFAULT '0',		
+		PRIMARY KEY (entry)
+	  )`
+
+	_, err = db.Exec(createTable)
+	return err
+}
+
+func ConvertIntSliceToString(slice []int) string {
+	sliceStr := make([]string, len(slice))
+	for i, v := range slice {
+		sliceStr[i] = strconv.Itoa(v)
+	}
+
+	return strings.Join(sliceStr, ",")
+}
+
+func insertItem(db *sql.DB, item sqlite.DungeonItem) {
+	_, err := db.Exec("INSERT OR IGNORE INTO dungeon_items (entry, mapId, expansion, dungeonLevel, creatureId, Quality) VALUES (?, ?, ?, ?, ?, ?)", item.Entry, item.MapId, item.Expansion, item.DungeonLevel, item.CreatureId, item.Quality)
+	if err != nil {
+		slog.Warn("failed to insert the dungeon item", logging.Entry, item.Entry, "err", err)
+	}
+}
+
+// Rebuilds the dungeon_items table of -items-db from the world database, the generator uses it to
+// tell dungeon and boss drops apart
+//
+//	item-gen crawl-dungeons -items-db data/items.db
+func run(g *cli.Globals, args []string) error {
+	fs := g.FlagSet("crawl-dungeons", "", "", "")
+	if err := g.Parse(fs, args, false); err != nil {
+		return err
+	}
+
+	liteDb, err := sql.Open("sqlite3", g.ItemsDb)
+	if err != nil {
+		return err
+	}
+	defer liteDb.Close()
+
+	worldDb, err := store.Open(g.Snapshot)
+	if err != nil {
+		return err
+	}
+	defer worldDb.Close()
+
+	// create the items table if it doesnt exist
+	if err := createTable(liteDb); err != nil {
+		return err
+	}
+
+	// Get all the dungeons and crawl to get all loot and add to sqlite
+	dungeons, err := worldDb.GetDungeons(-1)
+	if err != nil {
+		return fmt.Errorf("failed to get dungeons for expansion %v error: %v", 0, err)
+	}
+
+	for _, dungeon := range dungeons {
+
+		slog.Info("dungeon", "name", dungeon.Name, "map", dungeon.Id, "level", dungeon.Level)
+
+		bosses, err := worldDb.GetBosses(dungeon.Id)
+		if err != nil {
+			return fmt.Errorf("failed to get bosses of %v: %w", dungeon.Name, err)
+		}
+
+		for _, boss := range bosses {
+			dbItems, err := worldDb.GetBossLoot(boss.Entry)
+
+			if err != nil {
+				return fmt.Errorf("failed to get boss loot: %v error: %v", boss.Name, err)
+			}
+
+			for _, dungItem := range dbItems {
+				insertItem(liteDb, sqlite.DungeonItem{
+					Entry:        dungItem.Entry,
+					MapId:        dungeon.Id,
+					Quality:      *dungItem.Quality,
+					CreatureId:   boss.Entry,
+					Expansion:    dungeon.ExpansionId,
+					DungeonLevel: dungeon.Level,
+				})
+			}
+		}
+
+		dbItems, err := worldDb.GetAddlDungeonDrops(dungeon.Id)
+		if err != nil {
+			return fmt.Errorf("failed to get additional dungeon drops: %v error: %v", dungeon.Name, err)
+		}
+
+		for _, dungItem := range dbItems {
+			insertItem(liteDb, sqlite.DungeonItem{
+				Entry:        dungItem.Entry,
+				MapId:        dungeon.Id,
+				Quality:      *dungItem.Quality,
+				CreatureId:   0,
+				Expansion:    dungeon.ExpansionId,
+				DungeonLevel: dungeon.Level,
+			})
+
+			slog.Debug("dungeon item", logging.Entry, dungItem.Entry, "name", dungItem.Name, "map", dungeon.Id, "level", dungeon.Level)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package durability
+
+import (
+	"fmt"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/cli"
+	"github.com/araxiaonline/endgame-item-generator/internal/dbc"
+)
+
+var Command = cli.Command{
+	Name:    "durability",
+	Summary: "extend DurabilityCosts.dbc up to a new max item level",
+	Run:     run,
+}
+
+// Extends DurabilityCosts.dbc up to a new max item level, each new level costs a bit more than the last
+// row in the file so repairs keep scaling with the generated items.
+//
+//	item-gen durability -in ./dbc/DurabilityCosts.dbc -out DBFilesClient/DurabilityCosts.dbc
+func run(g *cli.Globals, args []string) error {
+	fs := g.FlagSet("durability", "", "where to write the extended DurabilityCosts.dbc, defaults to rewriting -in in place", "")
+	in := fs.String("in", "cmd/durability-costs/DurabilityCosts.dbc", "DurabilityCosts.dbc to extend")
+	maxLevel := fs.Int("max", 450, "highest item level to add a durability cost row for")
+	if err := g.Parse(fs, args, false); err != nil {
+		return err
+	}
+
+	out := g.Out
+	if out == "" {
+		out = *in
+	}
+
+	costs, err := dbc.ReadFile(*in, dbc.DurabilityCostsSchema)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Record count: %d\n", len(costs.Records))
+
+	if len(costs.Records) == 0 {
+		return fmt.Errorf("no durability cost rows to extend from in %s", *in)
+	}
+
+	last := costs.Records[len(costs.Records)-1]
+	lastId := last.ID()
+
+	for i := lastId + 1; i <= uint32(*maxLevel); i++ {
+		newRow := costs.Add()
+		newRow.CopyFrom(last)
+		newRow.SetUint32("ID", i)
+
+		for j := 1; j <= 21; j++ {
+			column := fmt.Sprintf("WeaponSubClassCost_%d", j)
+			newRow.SetUint32(column, last.Uint32(column)+20*(i-lastId))
+		}
+		for j := 1; j <= 8; j++ {
+			column := fmt.Sprintf("ArmorSubClassCost_%d", j)
+			newRow.SetUint32(column, last.Uint32(column)+10*(i-lastId))
+		}
+	}
+
+	if err := costs.WriteFile(out); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %d records to %s\n", len(costs.Records), out)
+	return nil
+}
@@ -1,11 +1,14 @@
-package main
+package emblem
 
 import (
-	"log"
+	"log/slog"
 	"math"
 
 	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/store"
+	"github.com/araxiaonline/endgame-item-generator/internal/ids"
 	"github.com/araxiaonline/endgame-item-generator/internal/items"
+	"github.com/araxiaonline/endgame-item-generator/internal/logging"
 	"github.com/araxiaonline/endgame-item-generator/internal/spells"
 )
 
@@ -50,22 +53,24 @@ func ComparePowerStats(newItem mysql.DbItem, oldGenItem mysql.DbItem, itemName s
 	// Check if we have a valid old item to compare with
 	isComparing := oldGenItem.Entry != 0
 
-	// Calculate the original entry ID by subtracting the bump (20000000) from old generated item
+	// Calculate the original entry ID from the mythic range the old generated item was written to
 	if isComparing {
-		originalEntry = oldGenItem.Entry - 20000000
+		originalEntry = ids.MythicItems.Source(oldGenItem.Entry)
 	} else {
 		// If we don't have an old item, try to use the new item's entry as the original
 		originalEntry = newItem.Entry
 	}
 
+	logger := slog.With(logging.Entry, entryID, logging.Stage, "compare")
+
 	// Try to get the original item to get its original level
 	originalItemLevel := 0
-	db, err := mysql.GetDb()
+	db, err := store.GetStore()
 	if err == nil {
 		originalItem, err := db.GetItem(originalEntry)
 		if err == nil && originalItem.ItemLevel != nil {
 			originalItemLevel = *originalItem.ItemLevel
-			log.Printf("  Original item found: Entry %d, Level %d", originalEntry, originalItemLevel)
+			logger.Debug("original item", "original", originalEntry, "itemLevel", originalItemLevel)
 		}
 	}
 
@@ -97,70 +102,39 @@ func ComparePowerStats(newItem mysql.DbItem, oldGenItem mysql.DbItem, itemName s
 		oldAttackPower += oldSpellAttackPower
 		oldSpellPower += oldSpellSpellPower
 
-		// Log Attack Power comparison if either item has it
+		// Log Attack Power comparison if either item has it, a warning when the new item has less
+		// despite an equal or higher item level
 		if oldAttackPower > 0 || newAttackPower > 0 {
-			log.Printf("===== ATTACK POWER COMPARISON: %s (Entry: %d) =====", itemName, entryID)
-			log.Printf("  Item Level: %d -> %d", oldItemLevel, newItemLevel)
-			log.Printf("  Attack Power: %d -> %d", oldAttackPower, newAttackPower)
-
-			// Log spell-based Attack Power if present
-			if oldSpellAttackPower > 0 || newSpellAttackPower > 0 {
-				log.Printf("  Attack Power from Spells: %d -> %d", oldSpellAttackPower, newSpellAttackPower)
-			}
-
-			// Highlight if new item has lower Attack Power despite equal or higher item level
+			attrs := []any{"name", itemName, "oldItemLevel", oldItemLevel, "itemLevel", newItemLevel,
+				"oldAttackPower", oldAttackPower, "attackPower", newAttackPower,
+				"oldSpellAttackPower", oldSpellAttackPower, "spellAttackPower", newSpellAttackPower}
 			if newItemLevel >= oldItemLevel && newAttackPower < oldAttackPower && newAttackPower > 0 {
-				log.Printf("  WARNING: Attack Power DECREASED despite item level being equal or higher!")
+				logger.Warn("attack power decreased despite an equal or higher item level", attrs...)
+			} else {
+				logger.Debug("attack power comparison", attrs...)
 			}
-			log.Printf("==========================================")
 		}
 
 		// Log Spell Power comparison if either item has it
 		if oldSpellPower > 0 || newSpellPower > 0 {
-			log.Printf("===== SPELL POWER COMPARISON: %s (Entry: %d) =====", itemName, entryID)
-			log.Printf("  Item Level: %d -> %d", oldItemLevel, newItemLevel)
-			log.Printf("  Spell Power: %d -> %d", oldSpellPower, newSpellPower)
-
-			// Log spell-based Spell Power if present
-			if oldSpellSpellPower > 0 || newSpellSpellPower > 0 {
-				log.Printf("  Spell Power from Spells: %d -> %d", oldSpellSpellPower, newSpellSpellPower)
-			}
-
-			// Highlight if new item has lower Spell Power despite equal or higher item level
+			attrs := []any{"name", itemName, "oldItemLevel", oldItemLevel, "itemLevel", newItemLevel,
+				"oldSpellPower", oldSpellPower, "spellPower", newSpellPower,
+				"oldSpellSpellPower", oldSpellSpellPower, "spellSpellPower", newSpellSpellPower}
 			if newItemLevel >= oldItemLevel && newSpellPower < oldSpellPower && newSpellPower > 0 {
-				log.Printf("  WARNING: Spell Power DECREASED despite item level being equal or higher!")
+				logger.Warn("spell power decreased despite an equal or higher item level", attrs...)
+			} else {
+				logger.Debug("spell power comparison", attrs...)
 			}
-			log.Printf("==========================================")
 		}
 	} else {
 		// If we don't have an old item to compare with, just show the new item's stats
-
-		// Log Attack Power if present
 		if newAttackPower > 0 {
-			log.Printf("===== NEW ITEM ATTACK POWER: %s (Entry: %d) =====", itemName, entryID)
-			log.Printf("  Item Level: %d", newItemLevel)
-			log.Printf("  Attack Power: %d", newAttackPower)
-
-			// Log spell-based Attack Power if present
-			if newSpellAttackPower > 0 {
-				log.Printf("  Attack Power from Spells: %d", newSpellAttackPower)
-				log.Printf("  Original Item Level: %d, New Item Level: %d", originalItemLevel, newItemLevel)
-			}
-			log.Printf("==========================================")
+			logger.Debug("new item attack power", "name", itemName, "itemLevel", newItemLevel, "originalItemLevel", originalItemLevel,
+				"attackPower", newAttackPower, "spellAttackPower", newSpellAttackPower)
 		}
-
-		// Log Spell Power if present
 		if newSpellPower > 0 {
-			log.Printf("===== NEW ITEM SPELL POWER: %s (Entry: %d) =====", itemName, entryID)
-			log.Printf("  Item Level: %d", newItemLevel)
-			log.Printf("  Spell Power: %d", newSpellPower)
-
-			// Log spell-based Spell Power if present
-			if newSpellSpellPower > 0 {
-				log.Printf("  Spell Power from Spells: %d", newSpellSpellPower)
-				log.Printf("  Original Item Level: %d, New Item Level: %d", originalItemLevel, newItemLevel)
-			}
-			log.Printf("==========================================")
+			logger.Debug("new item spell power", "name", itemName, "itemLevel", newItemLevel, "originalItemLevel", originalItemLevel,
+				"spellPower", newSpellPower, "spellSpellPower", newSpellSpellPower)
 		}
 	}
 }
@@ -233,15 +207,15 @@ func findPowerFromSpells(item *mysql.DbItem, originalItemLevel ...int) (attackPo
 		}
 
 		// Get the spell from the database
-		db, err := mysql.GetDb()
+		db, err := store.GetStore()
 		if err != nil {
-			log.Printf("Failed to get database connection: %v", err)
+			slog.Warn("failed to get the world database", logging.Entry, item.Entry, logging.Stage, "compare", "err", err)
 			continue
 		}
 
 		dbSpell, err := db.GetSpell(*spellID)
 		if err != nil {
-			log.Printf("Failed to get spell %d: %v", *spellID, err)
+			slog.Warn("failed to get the spell", logging.Entry, item.Entry, logging.Spell, *spellID, logging.Stage, "compare", "err", err)
 			continue
 		}
 
@@ -262,28 +236,21 @@ func findPowerFromSpells(item *mysql.DbItem, originalItemLevel ...int) (attackPo
 			// No tier specified - use base scaling only
 			err := scaledSpell.ForceScaleSpell(originalItemLevel[0], *item.ItemLevel, *item.Quality, 3)
 			if err == nil {
-				log.Printf("  Scaled spell %s (ID: %d) from level %d to %d using ForceScaleSpell",
-					scaledSpell.Name, scaledSpell.ID, originalItemLevel[0], *item.ItemLevel)
-
 				// Show before/after values for effect points
-				if spell.EffectBasePoints1 != 0 {
-					log.Printf("  Effect1: Original value: %d, Scaled value: %d", spell.EffectBasePoints1, scaledSpell.EffectBasePoints1)
-				}
-				if spell.EffectBasePoints2 != 0 {
-					log.Printf("  Effect2: Original value: %d, Scaled value: %d", spell.EffectBasePoints2, scaledSpell.EffectBasePoints2)
-				}
-				if spell.EffectBasePoints3 != 0 {
-					log.Printf("  Effect3: Original value: %d, Scaled value: %d", spell.EffectBasePoints3, scaledSpell.EffectBasePoints3)
-				}
+				slog.Debug("scaled spell", logging.Entry, item.Entry, logging.Spell, spell.ID, logging.Stage, "compare",
+					"name", spell.Name, "from", originalItemLevel[0], "itemLevel", *item.ItemLevel,
+					"basePoints", []int{spell.EffectBasePoints1, spell.EffectBasePoints2, spell.EffectBasePoints3},
+					"scaledBasePoints", []int{scaledSpell.EffectBasePoints1, scaledSpell.EffectBasePoints2, scaledSpell.EffectBasePoints3})
 			} else {
-				log.Printf("  Error scaling spell %s (ID: %d): %v", spell.Name, spell.ID, err)
+				slog.Warn("failed to scale the spell", logging.Entry, item.Entry, logging.Spell, spell.ID, logging.Stage, "compare",
+					"name", spell.Name, "err", err)
 			}
 		}
 
 		// Convert spell to stats
 		convStats, err := scaledSpell.ConvertToStats()
 		if err != nil {
-			log.Printf("  Error converting spell to stats: %v", err)
+			slog.Debug("spell has no stats", logging.Entry, item.Entry, logging.Spell, spell.ID, logging.Stage, "compare", "err", err)
 			continue
 		}
 
@@ -312,13 +279,11 @@ func findPowerFromSpells(item *mysql.DbItem, originalItemLevel ...int) (attackPo
 
 // logSpellDetails logs detailed information about a spell that adds power stats
 func logSpellDetails(spell spells.Spell, powerType string, powerValue int, item *mysql.DbItem, originalItemLevel ...int) {
-	// Log basic spell information
-	log.Printf("  Found %s spell: %s (ID: %d) adding %d %s Description: %s", powerType, spell.Name, spell.ID, powerValue, powerType, spell.Description)
+	logger := slog.With(logging.Entry, item.Entry, logging.Spell, spell.ID, logging.Stage, "compare")
 
-	// Log aura description if available
-	if spell.AuraDescription != "" {
-		log.Printf("    Aura: %s", spell.AuraDescription)
-	}
+	// Log basic spell information
+	logger.Debug("power spell", "name", spell.Name, "power", powerType, "value", powerValue,
+		"description", spell.Description, "aura", spell.AuraDescription)
 
 	// Show what the spell would look like if scaled
 	if item.ItemLevel != nil && item.Quality != nil {
@@ -329,7 +294,7 @@ func logSpellDetails(spell spells.Spell, powerType string, powerValue int, item
 		// If original item level is provided, use it as the starting point for scaling
 		if len(originalItemLevel) > 0 && originalItemLevel[0] > 0 {
 			startingLevel = originalItemLevel[0]
-			log.Printf("    Using original item level %d as scaling base (current level: %d)", startingLevel, currentLevel)
+			logger.Debug("scaling from the original item level", "from", startingLevel, "itemLevel", currentLevel)
 		}
 
 		qualModifier := 1.0
@@ -365,8 +330,8 @@ func logSpellDetails(spell spells.Spell, powerType string, powerValue int, item
 			// Simulate the spell scaling formula with enhanced scaling for large level jumps
 			scaledValue := int(float64(powerValue) * levelRatio * qualModifier * effectMultiplier)
 
-			log.Printf("    If scaled to item level %d: ~%d %s (%.2fx increase)",
-				newLevel, scaledValue, powerType, float64(scaledValue)/float64(powerValue))
+			logger.Debug("power if scaled", "itemLevel", newLevel, "power", powerType, "value", scaledValue,
+				"increase", float64(scaledValue)/float64(powerValue))
 		}
 	}
 }
@@ -0,0 +1,316 @@
+package emblem
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/cli"
+	"github.com/araxiaonline/endgame-item-generator/internal/config"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/apply"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlrow"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/store"
+	"github.com/araxiaonline/endgame-item-generator/internal/export"
+	"github.com/araxiaonline/endgame-item-generator/internal/ids"
+	"github.com/araxiaonline/endgame-item-generator/internal/items"
+	"github.com/araxiaonline/endgame-item-generator/internal/logging"
+	"github.com/araxiaonline/endgame-item-generator/internal/revert"
+	"github.com/araxiaonline/endgame-item-generator/internal/spells"
+	"github.com/gocarina/gocsv"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+var Command = cli.Command{
+	Name:    "emblem",
+	Summary: "write the emblem vendor items of a csv tier to the world database",
+	Run:     run,
+}
+
+// This will accept a list of existing items pre-scaled by ChatGPT and scale stats
+// based on our server modifiers and tier modifiers. Sample files are in cmd/create_emblem_items.
+//
+//	item-gen emblem -filename cmd/create_emblem_items/mythic-items.csv -tier 1
+func run(g *cli.Globals, args []string) error {
+	fs := g.FlagSet("emblem", "", "", "")
+	filename := fs.String("filename", "", "csv of the items to read in")
+	tier := fs.Int("tier", 1, "tier of the items to read in")
+	itemDbcPath := fs.String("itemdbc", "", "client Item.dbc to add the new vendor items to")
+	itemDbcOut := fs.String("itemdbc-out", "DBFilesClient/Item.dbc", "where to write the patched Item.dbc when -itemdbc is set")
+	spellDbcPath := fs.String("spelldbc", "", "client Spell.dbc to add the scaled vendor spells to, enables the Spell.dbc patch output")
+	spellDbcOut := fs.String("spelldbc-out", "DBFilesClient/Spell.dbc", "where to write the patched Spell.dbc when -spelldbc is set")
+	scalerName := fs.String("scaler", items.DefaultScaler, fmt.Sprintf("stat scaling formula, one of %s", strings.Join(items.ScalerNames(), ", ")))
+	dryRun := fs.Bool("dry-run", false, "do every write then roll back and only print the row counts")
+	revertPath := fs.String("revert", "", "new file to write the sql that undoes this run, empty to skip")
+	format := fs.String("format", "db", "db writes the items, json prints every item and where its values came from and only reads the world database")
+	if err := g.Parse(fs, args, true); err != nil {
+		return err
+	}
+
+	// json prints the items instead of writing them
+	var document *export.Document
+	switch *format {
+	case "db":
+	case "json":
+		document = export.NewDocument("create_emblem_items", *scalerName)
+	default:
+		return fmt.Errorf("unknown -format %s, use db or json", *format)
+	}
+
+	profile, err := config.LoadProfile(g.Profile)
+	if err != nil {
+		return err
+	}
+	config.Use(profile)
+	ids.Use(ids.EmblemVendor)
+
+	// the csv stats come in already scaled so this only matters for items that go through ScaleItem
+	if err := items.UseScaler(*scalerName); err != nil {
+		return err
+	}
+
+	if *filename == "" {
+		return errors.New("item file is required")
+	}
+
+	itemsFile, err := os.Open(*filename)
+	if err != nil {
+		return err
+	}
+	defer itemsFile.Close()
+
+	// Connection to mysql database or a local world snapshot
+	worldDb, err := store.Open(g.Snapshot)
+	if err != nil {
+		return err
+	}
+	defer worldDb.Close()
+
+	// every spell and item write of the run goes in one transaction, a failure rolls back the whole tier.
+	// json does not write so it reads the world database without one.
+	var run *apply.Run
+	if document == nil {
+		var tx store.Tx
+		run, tx, err = apply.Begin(worldDb, *dryRun)
+		if err != nil {
+			return err
+		}
+		worldDb = tx
+	}
+	abort := func(err error) error {
+		if run == nil {
+			return err
+		}
+		if err := run.Rollback(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		run.PrintSummary(os.Stderr)
+		return fmt.Errorf("%v, rolled back, nothing was written", err)
+	}
+
+	// the id checks and the revert script read the world database directly
+	querier, ok := worldDb.(sqlrow.Querier)
+	if !ok {
+		return abort(errors.New("the world database can not be read directly"))
+	}
+
+	// rows are read before the run touches them so the revert script can put them back
+	var revertScript *revert.Script
+	if *revertPath != "" && !*dryRun && document == nil {
+		if err := revert.Free(*revertPath); err != nil {
+			return abort(err)
+		}
+		revertScript = revert.New(querier, "")
+	}
+
+	// writeRow puts the row in the revert script and writes it in the run, json skips the write
+	writeRow := func(table, key string, id int, write func() error) error {
+		if run == nil {
+			return nil
+		}
+		if revertScript != nil {
+			if err := revertScript.Track(table, key, id); err != nil {
+				return err
+			}
+		}
+		return run.Track(table, key, id, write)
+	}
+
+	// Item.dbc patch so the vendor items show their model before the client caches them
+	var itemDbc *items.ItemDbc
+	if *itemDbcPath != "" {
+		itemDbc, err = items.OpenItemDbc(*itemDbcPath)
+		if err != nil {
+			return abort(err)
+		}
+		if err := itemDbc.Merge(*itemDbcOut); err != nil {
+			return abort(err)
+		}
+	}
+	var spellDbc *spells.SpellDbc
+	if *spellDbcPath != "" {
+		spellDbc, err = spells.OpenSpellDbc(*spellDbcPath)
+		if err != nil {
+			return abort(err)
+		}
+		if err := spellDbc.Merge(*spellDbcOut); err != nil {
+			return abort(err)
+		}
+	}
+
+	csvItems := []*mysql.DbItemCsv{}
+
+	if err := gocsv.UnmarshalFile(itemsFile, &csvItems); err != nil { // Load items from file
+		return abort(err)
+	}
+
+	for _, item := range csvItems {
+		// ConvertCsvToDbItem already tries to find the original item and preserve its fields
+		dbItem, err := mysql.ConvertCsvToDbItem(worldDb, *item)
+		if err != nil {
+			slog.Warn("failed to convert the csv row", logging.Entry, item.Entry, logging.Stage, "import", "name", item.Name, "err", err)
+			continue
+		}
+
+		// Get the original item for reference (e.g., for scaling calculations)
+		originalEntry := ids.VendorItems.Source(item.Entry)
+		logger := slog.With(logging.Entry, originalEntry)
+		originalItem, err := worldDb.GetItem(originalEntry)
+		if err != nil {
+			logger.Warn("failed to get the original item", logging.Stage, "import", "name", item.Name, "err", err)
+			continue
+		}
+
+		// Create a new item from the dbItem (which already has preserved fields)
+		newItem := items.ItemFromDbItem(dbItem)
+
+		// Scale armor based on the new item level
+		newItem.ScaleArmor(*item.ItemLevel)
+
+		if *newItem.Class == 2 && *newItem.MinDmg1 > 0 {
+			_, err := newItem.ScaleDPS(*originalItem.ItemLevel, *item.ItemLevel)
+			if err != nil {
+				logger.Warn("failed to scale the dps", logging.Stage, "scale", "err", err)
+			} else {
+				logger.Debug("scaled dps", logging.Stage, "scale", "name", item.Name,
+					"oldDamage", []float64{*originalItem.MinDmg1, *originalItem.MaxDmg1}, "damage", []float64{*newItem.MinDmg1, *newItem.MaxDmg1})
+			}
+		}
+
+		// Now need to apply tier modifiers and stat modifiers to the items
+		newItem.ApplyTierModifiers(*tier)
+
+		// Make a copy of the spells for the new item
+		spellList, err := newItem.GetSpells()
+		if err != nil {
+			logger.Warn("failed to get the item's spells", logging.Stage, "spells", "name", item.Name, "err", err)
+			continue
+		}
+
+		scaledSpells := []spells.Spell{}
+		for _, spell := range spellList {
+
+			newSpellId, err := ids.VendorSpells.Allocate(spell.ID)
+			if err != nil {
+				return abort(err)
+			}
+			if err := ids.VendorSpells.Check(querier, spell.ID, newSpellId); err != nil {
+				return abort(err)
+			}
+
+			// Scale the spell now and replace the key scaling aspects.
+			originalSpell := spell.ID
+			spell.ForceScaleSpell(*originalItem.ItemLevel, *newItem.ItemLevel, *newItem.Quality, *tier)
+
+			// Create a copy of the spell with the new ID for the update
+			scaledSpell := spell.DbSpell
+			scaledSpell.ID = newSpellId
+
+			// Copy the spell to the new vendor table (why vendor... not sure just random I guess I made up)
+			// then write the scaled spell values over the copy
+			err = writeRow("spells_new_vendor", "ID", newSpellId, func() error {
+				if err := worldDb.CopySpell("spell_dbc", "spells_new_vendor", originalSpell, newSpellId); err != nil {
+					return err
+				}
+				return worldDb.WriteSpell("spells_new_vendor", scaledSpell)
+			})
+			if err != nil {
+				return abort(err)
+			}
+
+			// Update the original newItem spellID with the new scaled spell ID
+			newItem.UpdateSpellID(spell.ID, newSpellId)
+			spell.ScaledId = newSpellId
+			scaledSpells = append(scaledSpells, spell)
+
+			if spellDbc != nil {
+				if err := spellDbc.Add(spell, newSpellId); err != nil {
+					return abort(err)
+				}
+			}
+		}
+
+		// First, copy the original item to preserve all fields then write the updated item to override specific fields
+		newEntry, err := ids.VendorItems.Allocate(originalEntry)
+		if err != nil {
+			return abort(err)
+		}
+		if err := ids.VendorItems.Check(querier, originalEntry, newEntry); err != nil {
+			return abort(err)
+		}
+		newItem.DbItem.Entry = newEntry
+		err = writeRow("item_template_new_vendor", "entry", newEntry, func() error {
+			if err := worldDb.CopyItem("item_template", "item_template_new_vendor", originalEntry, newEntry); err != nil {
+				return err
+			}
+			return worldDb.WriteItem("item_template_new_vendor", newItem.DbItem)
+		})
+		if err != nil {
+			return abort(err)
+		}
+		logger.Info("wrote the vendor item", logging.Stage, "write", "name", item.Name, "vendorEntry", newEntry)
+
+		if itemDbc != nil {
+			if err := itemDbc.Add(newItem.DbItem, newEntry); err != nil {
+				return abort(err)
+			}
+		}
+
+		if document != nil {
+			newItem.Spells = scaledSpells
+			document.Add(export.NewWrittenRecord(newItem, newEntry, originalEntry, *tier, export.NewReference("GetItem", originalItem)))
+		}
+	}
+
+	if document != nil {
+		return document.WriteJson(os.Stdout)
+	}
+	if err := run.Finish(); err != nil {
+		return abort(err)
+	}
+	run.PrintSummary(os.Stdout)
+
+	if revertScript != nil {
+		if err := revertScript.WriteFile(*revertPath); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote the revert script for %d rows to %s\n", revertScript.Count(), *revertPath)
+	}
+
+	if itemDbc != nil && !*dryRun {
+		if err := itemDbc.WriteFile(*itemDbcOut); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %d items to %s\n", itemDbc.Count(), *itemDbcOut)
+	}
+	if spellDbc != nil && !*dryRun {
+		if err := spellDbc.WriteFile(*spellDbcOut); err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %d scaled spells to %s\n", spellDbc.Count(), *spellDbcOut)
+	}
+	return nil
+}
@@ -0,0 +1,669 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/cli"
+	"github.com/araxiaonline/endgame-item-generator/internal/config"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/apply"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlite"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlrow"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/store"
+	"github.com/araxiaonline/endgame-item-generator/internal/export"
+	"github.com/araxiaonline/endgame-item-generator/internal/filter"
+	"github.com/araxiaonline/endgame-item-generator/internal/ids"
+	"github.com/araxiaonline/endgame-item-generator/internal/items"
+	"github.com/araxiaonline/endgame-item-generator/internal/locale"
+	"github.com/araxiaonline/endgame-item-generator/internal/logging"
+	"github.com/araxiaonline/endgame-item-generator/internal/naming"
+	"github.com/araxiaonline/endgame-item-generator/internal/pipeline"
+	"github.com/araxiaonline/endgame-item-generator/internal/report"
+	"github.com/araxiaonline/endgame-item-generator/internal/revert"
+	"github.com/araxiaonline/endgame-item-generator/internal/rng"
+	"github.com/araxiaonline/endgame-item-generator/internal/spells"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+var Command = cli.Command{
+	Name:    "generate",
+	Summary: "scale the dungeon items up to a difficulty and print the sql",
+	Run:     run,
+}
+
+// Scales every rare and better dungeon item up to the item level of a difficulty and prints the sql
+// that creates the new items
+//
+//	item-gen generate -difficulty 3 -out mythic.sql
+func run(g *cli.Globals, args []string) error {
+	fs := g.FlagSet("generate", "", "", "")
+	difficulty := fs.Int("difficulty", 3, "set the difficulty of the dungeon, defaults to 3 (mythic) 4 (legendary) 5 (ascendant)")
+	// levelUp := fs.Bool("levelUp", false, "Boss items require higher +1 level to equip, defaults to false")
+	baselevel := fs.Int("baselevel", 80, "set the base level for items to be used, defaults to 80 this is required for levelUp flag")
+	spellDbcPath := fs.String("spelldbc", "", "client Spell.dbc to add the scaled spells to, enables the Spell.dbc patch output")
+	spellDbcOut := fs.String("spelldbc-out", "DBFilesClient/Spell.dbc", "where to write the patched Spell.dbc when -spelldbc is set")
+	itemDbcPath := fs.String("itemdbc", "", "client Item.dbc to add the generated items to, enables the Item.dbc patch output")
+	itemDbcOut := fs.String("itemdbc-out", "DBFilesClient/Item.dbc", "where to write the patched Item.dbc when -itemdbc is set")
+	scalerName := fs.String("scaler", items.DefaultScaler, fmt.Sprintf("stat scaling formula, one of %s", strings.Join(items.ScalerNames(), ", ")))
+	seed := fs.Uint64("seed", 0, "seed for every random choice (names, sell prices, stat templates), the same seed, profile and database give the same sql. 0 picks one from the clock")
+	applyRun := fs.Bool("apply", false, "write the spells and items straight to the MySQL world database in one transaction instead of printing sql")
+	dryRun := fs.Bool("dry-run", false, "with -apply, do every write then roll back and only print the row counts")
+	spellIdsPath := fs.String("spell-ids", "", "file that keeps the id every scaled spell variant was given so reruns reuse them, empty keeps them for this run only")
+	revertPath := fs.String("revert", "", "new file to write the sql that deletes the rows this run creates and restores the ones it overwrites, empty to skip")
+	format := fs.String("format", "copy", "output: copy (sql that copies the source rows and updates them), insert (sql with complete rows that do not need the source rows) json (every generated item with where its values came from) or csv (the emblem importer's spreadsheet layout)")
+	batchSize := fs.Int("batch", 100, "rows per INSERT with -format insert")
+	printProfile := fs.Bool("print-profile", false, "print the resolved generation profile and exit")
+	localesPath := fs.String("locales", "", "json word lists for the translated item_template_locale names, defaults to the built in lists (see locales/default.json), none to skip the translations")
+	namesPath := fs.String("names", "", "json word pools the generated item names are picked from, defaults to the built in pools (see names/default.json)")
+	preloadItems := fs.Bool("preload-items", false, "read all of item_template and its spells up front in two queries instead of one query per reference item")
+	workers := fs.Int("workers", 1, "items looked up and scaled at the same time, the output is the same for any number")
+	reportPath := fs.String("report", "", "where to write what happened to every source item and why it was skipped, .csv for a spreadsheet, empty to skip")
+	where := fs.String("where", "", "only generate the items that match, like 'map=289 and class=4 and subclass=4' or 'role=caster and slot=12 and expansion=1 and drop in (boss, final-boss)', fields are "+strings.Join(whereFields.Names(), ", "))
+	spellLocales := fs.String("spell-locales", "", "comma separated client Spell.dbc files of localized clients to copy the translated spell names and descriptions from")
+	if err := g.Parse(fs, args, true); err != nil {
+		return err
+	}
+
+	profile, err := config.LoadProfile(g.Profile)
+	if err != nil {
+		return err
+	}
+	config.Use(profile)
+
+	if err := items.UseScaler(*scalerName); err != nil {
+		return err
+	}
+
+	itemFilter, err := filter.Parse(*where, whereFields)
+	if err != nil {
+		return fmt.Errorf("-where: %w", err)
+	}
+
+	if *printProfile {
+		fmt.Println(profile)
+		return nil
+	}
+
+	// generated items get a translated name for every locale with a word list
+	words := locale.Words{}
+	if *localesPath != "none" {
+		words, err = locale.Load(*localesPath)
+		if err != nil {
+			return err
+		}
+	}
+	locale.Use(words)
+
+	names, err := naming.Load(*namesPath)
+	if err != nil {
+		return err
+	}
+
+	if *spellLocales != "" {
+		texts, err := spells.LoadTexts(strings.Split(*spellLocales, ",")...)
+		if err != nil {
+			return err
+		}
+		spells.UseTexts(texts)
+	}
+
+	if *seed != 0 {
+		rng.Seed(*seed)
+	}
+
+	// json and csv are collected into an export document instead of printing sql
+	exporting := *format == "json" || *format == "csv"
+
+	// sql comments are left out of the json and csv output, json carries the seed and profile itself
+	sqlComment := func(layout string, args ...interface{}) {
+		if !exporting {
+			fmt.Printf(layout, args...)
+		}
+	}
+
+	if difficulty == nil || *difficulty < 3 || *difficulty > 5 {
+		return errors.New("difficulty must be between 3-5")
+	}
+
+	if *format != "copy" && *format != "insert" && !exporting {
+		return fmt.Errorf("unknown -format %s, use copy, insert, json or csv", *format)
+	}
+
+	// the sql records the seed and profile it was generated with so the run can be repeated
+	sqlComment("-- seed: %d\n", rng.CurrentSeed())
+	sqlComment("/* generation profile %s v%d\n%s\n*/\n", profile.Name, profile.Version, profile)
+	if itemFilter != nil {
+		sqlComment("-- where: %s\n", itemFilter)
+	}
+
+	if baselevel == nil || *baselevel < 0 {
+		return errors.New("base level must be greater than 80")
+	}
+
+	var itemLevel *int = new(int)
+	switch *difficulty {
+	case 3:
+		*itemLevel = config.MythicItemLevelStart
+	case 4:
+		*itemLevel = config.LegendaryItemLevelStart
+	case 5:
+		*itemLevel = config.AscendantItemLevelStart
+	}
+
+	// Connect to Mysql or the local world snapshot when one is given
+	worldDb, err := store.Open(g.Snapshot)
+	if err != nil {
+		return err
+	}
+	defer worldDb.Close()
+
+	// -apply writes through one transaction, any failed write rolls back the whole run. Every read and
+	// write from here on goes through it.
+	var run *apply.Run
+	if *applyRun || *dryRun {
+		if g.Snapshot != "" {
+			return errors.New("-apply writes MySQL sql and can not be used with -snapshot")
+		}
+		if *format != "copy" {
+			return errors.New("-format only changes the printed output and can not be used with -apply")
+		}
+		var tx store.Tx
+		run, tx, err = apply.Begin(worldDb, *dryRun)
+		if err != nil {
+			return err
+		}
+		worldDb = tx
+	}
+	// rolls -apply back and hands the error on to be returned
+	abort := func(err error) error {
+		if run != nil {
+			if err := run.Rollback(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			run.PrintSummary(os.Stderr)
+			fmt.Fprintln(os.Stderr, "rolled back, nothing was written")
+		}
+		return err
+	}
+
+	// the items and spells scaling reads go through one cache, see store.Cache
+	cache := store.NewCache(worldDb)
+	store.Use(cache)
+	if *preloadItems {
+		if err := cache.LoadItems(); err != nil {
+			return abort(err)
+		}
+	}
+
+	// the taken names, id checks, revert script and -format insert rows are read straight from the world database
+	querier, ok := worldDb.(sqlrow.Querier)
+	if !ok {
+		return abort(errors.New("the world database can not be read directly"))
+	}
+
+	// generated names have to differ from every item already in item_template
+	namer := naming.NewNamer(names)
+	if err := namer.LoadTaken(querier); err != nil {
+		return abort(err)
+	}
+	naming.Use(namer)
+
+	// Connect to SqlList for EndGame Mapping
+	sqliteDb, err := sqlite.Connect(g.ItemsDb)
+	if err != nil {
+		return abort(err)
+	}
+
+	// Client Spell.dbc and Item.dbc patches so tooltips and models match the generated rows
+	var spellDbc *spells.SpellDbc
+	if *spellDbcPath != "" {
+		spellDbc, err = spells.OpenSpellDbc(*spellDbcPath)
+		if err != nil {
+			return abort(err)
+		}
+		if err := spellDbc.Merge(*spellDbcOut); err != nil {
+			return abort(err)
+		}
+	}
+	var itemDbc *items.ItemDbc
+	if *itemDbcPath != "" {
+		itemDbc, err = items.OpenItemDbc(*itemDbcPath)
+		if err != nil {
+			return abort(err)
+		}
+		if err := itemDbc.Merge(*itemDbcOut); err != nil {
+			return abort(err)
+		}
+	}
+	dbcErrors := []error{}
+
+	// every item and spell row the run writes is read first so it can be put back
+	var revertScript *revert.Script
+	if *revertPath != "" && !*dryRun && !exporting {
+		if err := revert.Free(*revertPath); err != nil {
+			return abort(err)
+		}
+		revertScript = revert.New(querier, "acore_world")
+	}
+
+	// scaled spells get one id per spell and effect values, kept between runs with -spell-ids
+	spellIds := ids.NewMap("")
+	if *spellIdsPath != "" {
+		spellIds, err = ids.LoadMap(*spellIdsPath)
+		if err != nil {
+			return abort(err)
+		}
+	}
+	spells.UseIdMap(spellIds)
+
+	// -format insert prints every spell and item as a full row, batched per table
+	var spellRows, itemRows, itemLocaleRows *sqlrow.Batch
+	if *format == "insert" {
+		spellRows = sqlrow.NewBatch(os.Stdout, "acore_world.spell_dbc", "ID", *batchSize)
+		itemRows = sqlrow.NewBatch(os.Stdout, "acore_world.item_template", "entry", *batchSize)
+		itemLocaleRows = sqlrow.NewBatch(os.Stdout, "acore_world.item_template_locale", "ID", *batchSize)
+		itemLocaleRows.Unique = []string{"locale"}
+	}
+
+	var document *export.Document
+	if exporting {
+		document = export.NewDocument("item-gen", *scalerName)
+	}
+
+	// prints the item sql or json, or applies it, and adds the item and its scaled spells to the dbc patches
+	writeItem := func(item items.Item, reqLevel int, ref *export.Reference) error {
+		if err := item.AllocateSpellIds(); err != nil {
+			return err
+		}
+		for _, spell := range item.Spells {
+			if err := ids.Check(querier, ids.Spells, spell.ID, spell.WriteId(*item.Quality)); err != nil {
+				return err
+			}
+		}
+		if err := ids.Check(querier, ids.Items, item.Entry, items.GeneratedEntry(item.Entry, *difficulty)); err != nil {
+			return err
+		}
+
+		if revertScript != nil {
+			for _, spell := range item.Spells {
+				if err := revertScript.Track("spell_dbc", "ID", spell.WriteId(*item.Quality)); err != nil {
+					return err
+				}
+			}
+			if err := revertScript.Track("item_template", "entry", items.GeneratedEntry(item.Entry, *difficulty)); err != nil {
+				return err
+			}
+			if len(locale.Current()) > 0 {
+				if err := revertScript.TrackAll("item_template_locale", "ID", items.GeneratedEntry(item.Entry, *difficulty)); err != nil {
+					return err
+				}
+			}
+		}
+
+		if document != nil {
+			document.Add(export.NewRecord(item, reqLevel, *difficulty, ref))
+		} else if run == nil && itemRows != nil {
+			for _, spell := range item.Spells {
+				row, err := spells.SpellRow(querier, spell, *item.Quality)
+				if err != nil {
+					return err
+				}
+				if err := spellRows.Add(row); err != nil {
+					return err
+				}
+			}
+			row, localeRows, err := items.ItemRow(querier, item, reqLevel, *difficulty)
+			if err != nil {
+				return err
+			}
+			if err := itemRows.Add(row); err != nil {
+				return err
+			}
+			for _, row := range localeRows {
+				if err := itemLocaleRows.Add(row); err != nil {
+					return err
+				}
+			}
+		} else if run == nil {
+			fmt.Print(items.ItemToSql(item, reqLevel, *difficulty))
+		} else {
+			for _, spell := range item.Spells {
+				if err := run.Exec("acore_world.spell_dbc", "ID", spell.WriteId(*item.Quality), spells.SpellStatements(spell, *item.Quality)...); err != nil {
+					return err
+				}
+			}
+			if err := run.Exec("acore_world.item_template", "entry", items.GeneratedEntry(item.Entry, *difficulty), items.ItemStatements(item, reqLevel, *difficulty)...); err != nil {
+				return err
+			}
+		}
+
+		if itemDbc != nil {
+			if err := itemDbc.Add(item.DbItem, items.GeneratedEntry(item.Entry, *difficulty)); err != nil {
+				dbcErrors = append(dbcErrors, err)
+			}
+		}
+		if spellDbc != nil {
+			for _, spell := range item.Spells {
+				if err := spellDbc.Add(spell, spell.WriteId(*item.Quality)); err != nil {
+					dbcErrors = append(dbcErrors, err)
+				}
+			}
+		}
+		return nil
+	}
+
+	// Get all rare items int the acore_world.item_template that are rare or higher quality, the parts of
+	// -where the world and items databases can answer narrow the query and the rest is checked per item
+	itemWhere, itemArgs := itemFilter.Clause("item_template", nil)
+	if dungeonWhere, dungeonArgs := itemFilter.Clause("dungeon_items", notInDungeon); dungeonWhere != "" {
+		entries, err := sqliteDb.GetDungeonEntries(dungeonWhere, dungeonArgs)
+		if err != nil {
+			return abort(err)
+		}
+		inDungeons := "1 = 0"
+		if len(entries) > 0 {
+			list := make([]string, len(entries))
+			for i, entry := range entries {
+				list[i] = strconv.Itoa(entry)
+			}
+			inDungeons = "entry IN (" + strings.Join(list, ",") + ")"
+		}
+		if itemWhere == "" {
+			itemWhere = inDungeons
+		} else {
+			itemWhere += " AND " + inDungeons
+		}
+	}
+	rareItems, err := cache.GetRarePlusItems(itemWhere, itemArgs, 0, 0)
+	if err != nil {
+		return abort(err)
+	}
+	// the spells of every item to scale in one query instead of a few per item
+	if err := cache.LoadSpellsOf(rareItems); err != nil {
+		return abort(err)
+	}
+
+	// an item scaled by a worker, waiting to be written with the sql comments that go around it
+	type scaled struct {
+		item     items.Item
+		reqLevel int
+		ref      *export.Reference
+		write    bool
+		comment  string // printed before the item
+		updated  bool   // prints the Item Updated comment after the item
+		filtered bool   // left out by -where, not in the report
+		outcome  report.Outcome
+	}
+
+	// what happened to every source item, written at the end so the gaps can be found
+	runReport := report.New("endgame-item-generator", rng.CurrentSeed(), *difficulty)
+	runReport.Where = itemFilter.String()
+
+	// do scaling for all items that are processed from the rareItems list
+	prepare := func(dbItem mysql.DbItem) (result scaled) {
+
+		// convert from a dbModel item to Item entity
+		item := items.ItemFromDbItem(dbItem)
+		result.outcome = report.Outcome{Entry: item.Entry, Name: item.Name}
+		skip := func(reason, detail string) {
+			result.outcome.Status, result.outcome.Reason, result.outcome.Detail = report.Skipped, reason, detail
+		}
+		fail := func(reason, detail string) {
+			result.outcome.Status, result.outcome.Reason, result.outcome.Detail = report.Failed, reason, detail
+		}
+
+		// random picks for this item come from its own source so they do not depend on the order the workers finish in
+		source := rng.For(item.Entry)
+		logger := slog.With(logging.Entry, item.Entry, logging.Difficulty, *difficulty)
+
+		// the lookup Item is a check to see if the item comes from a dungeon on higher difficulties (4,5) we only process dungeon items
+		lookupItem, lookupErr := sqliteDb.GetItemFromDungeon(item.Entry)
+		if lookupErr != nil {
+			if !strings.Contains(lookupErr.Error(), "no rows in result set") {
+				logger.Error("failed to look up the item in the dungeon items", logging.Stage, "lookup", "err", lookupErr)
+			} else {
+				lookupErr = nil
+			}
+		}
+		logger.Debug("dungeon lookup", logging.Stage, "lookup", "map", lookupItem.MapId, "boss", lookupItem.CreatureId)
+
+		drop := dropOf(lookupItem, *difficulty)
+		if !itemFilter.Match(whereValues(&item, lookupItem, drop)) {
+			logger.Debug("left out by -where", logging.Stage, "lookup", "name", item.Name)
+			result.filtered = true
+			return
+		}
+
+		// skip items not from a dungeon on higher difficulties
+		if *difficulty > 3 {
+			if lookupItem.Entry == 0 {
+				logger.Debug("not from a dungeon", logging.Stage, "lookup", "name", item.Name)
+				if lookupErr != nil {
+					fail(report.DungeonLookupError, lookupErr.Error())
+				} else {
+					skip(report.NotFromDungeon, "")
+				}
+				return
+			}
+		}
+
+		// if it is a rare item then we need to scale it up to epic
+		if *item.Quality < 5 {
+			*item.Quality = 4
+		}
+
+		statsList, err := item.GetStatList()
+		if err != nil {
+			logger.Warn("failed to get the stat list", logging.Stage, "stats", "err", err)
+			skip(report.NoStatList, err.Error())
+			return
+		}
+
+		logger.Debug("stat list", logging.Stage, "stats", "name", item.Name, "stats", statsList)
+
+		var highLevelItem mysql.DbItem
+		if *difficulty == 3 {
+			rndItem, err := sqliteDb.GetRandItem(source, *item.Class, *item.Subclass, statsList, false)
+			if err != nil {
+				logger.Warn("no reference item", logging.Stage, "reference", "err", err)
+				skip(report.NoReference, err.Error())
+				return
+			}
+
+			if rndItem == (sqlite.HighLevelItem{}) {
+				logger.Error("the random item lookup came back empty", logging.Stage, "reference")
+				fail(report.ReferenceMissing, "the random item lookup came back empty")
+				return
+			}
+
+			logger.Debug("reference item", logging.Stage, "reference", "reference", rndItem.Entry, "name", rndItem.Name)
+
+			// Take the high level item that has been selected for stats and remap to current item
+			highLevelItem, err = cache.GetItem(rndItem.Entry)
+			if err != nil {
+				logger.Error("failed to read the reference item", logging.Stage, "reference", "reference", rndItem.Entry, "err", err)
+				fail(report.ReferenceMissing, err.Error())
+				return
+			}
+			result.ref = export.NewReference("GetRandItem", highLevelItem)
+		} else {
+
+			highLevelItem, err = cache.GetByNameAndDifficulty(item.Name, *difficulty-1)
+			if err != nil {
+				logger.Warn("no item on the lower difficulty", logging.Stage, "reference", "err", err)
+				skip(report.NoLowerDifficulty, err.Error())
+				return
+			}
+			result.ref = export.NewReference("GetByNameAndDifficulty", highLevelItem)
+		}
+
+		// difficulty is used to tweak things in the scaling proces specifically modifiers so stats are not inflated twice by quality multiples
+		item.SetDifficulty(*difficulty)
+		// the dungeon picks the name theme
+		item.SetMap(lookupItem.MapId)
+
+		// the item rules of the profile set the item level, required level and quality from where the item drops
+		reason := dropReasons[drop.Kind]
+		if lookupItem.Entry != 0 {
+			result.outcome.Map, result.outcome.Boss = lookupItem.MapId, lookupItem.CreatureId
+		}
+		if drop.Kind == config.FinalBossDrop {
+			result.comment = fmt.Sprintf("-- Final Boss Item: %v Entry: %v difficulty %v\n", item.Name, item.Entry, *difficulty)
+		}
+
+		rule, ok := profile.MatchRules(drop)
+		if !ok {
+			logger.Warn("no item rule matches", logging.Stage, "rules", "name", item.Name, "drop", drop.String(), "map", lookupItem.MapId)
+			skip(report.NoItemRule, drop.String())
+		} else {
+			logger.Debug("item rules", logging.Stage, "rules", "rules", rule.Rules, "itemLevel", rule.ItemLevel, "requiredLevel", rule.RequiredLevel)
+			quality := *item.Quality
+			if rule.Quality != 0 {
+				quality = rule.Quality
+			}
+			if err := Scale(highLevelItem, &item, *itemLevel+rule.ItemLevel, quality); err != nil {
+				logger.Error("failed to scale the item", logging.Stage, "scale", "name", item.Name, "err", err)
+				fail(report.ScaleFailed, err.Error())
+				return
+			}
+			result.reqLevel, result.write = *baselevel+rule.RequiredLevel, true
+			result.outcome.Status, result.outcome.Reason = report.Generated, reason
+			if lookupErr != nil {
+				result.outcome.Detail = lookupErr.Error()
+			}
+		}
+
+		// only written dungeon items get the updated comment
+		result.item, result.updated = item, result.write && lookupItem.Entry != 0
+		return
+	}
+
+	// the printed output and the -apply transaction are only finished once every item was written
+	finish := func() error {
+		if document != nil {
+			write := document.WriteJson
+			if *format == "csv" {
+				write = document.WriteCsv
+			}
+			if err := write(os.Stdout); err != nil {
+				return err
+			}
+		}
+
+		if itemRows != nil {
+			if err := spellRows.Flush(); err != nil {
+				return err
+			}
+			if err := itemRows.Flush(); err != nil {
+				return err
+			}
+			if err := itemLocaleRows.Flush(); err != nil {
+				return err
+			}
+		}
+
+		if len(dbcErrors) > 0 {
+			for _, err := range dbcErrors {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			return fmt.Errorf("%d dbc errors, no dbc patches were written", len(dbcErrors))
+		}
+
+		if run != nil {
+			if err := run.Finish(); err != nil {
+				return err
+			}
+			run.PrintSummary(os.Stdout)
+		}
+		return nil
+	}
+
+	// the lookups and scaling run on -workers goroutines, the items are written one at a time in the
+	// order they were read so the output is the same as a run with one worker
+	err = pipeline.Ordered(rareItems, *workers, prepare, func(result scaled) error {
+		if result.filtered {
+			return nil
+		}
+		if result.comment != "" {
+			sqlComment("%s", result.comment)
+		}
+		if result.write {
+			if err := writeItem(result.item, result.reqLevel, result.ref); err != nil {
+				return err
+			}
+			result.outcome.GeneratedEntry = items.GeneratedEntry(result.item.Entry, *difficulty)
+			result.outcome.ItemLevel = *result.item.ItemLevel
+			result.outcome.RequiredLevel = result.reqLevel
+			if result.ref != nil {
+				result.outcome.Reference = result.ref.Entry
+			}
+		}
+		runReport.Add(result.outcome)
+		if result.updated {
+			sqlComment("\n -- Item Updated: %v Entry: %v\n", result.item.Name, result.item.Entry)
+		}
+		return nil
+	})
+	if err == nil {
+		err = finish()
+	}
+	// a failed run still keeps its report, revert script and spell ids, only the dbc patches are left out
+	if err != nil {
+		err = abort(err)
+	}
+
+	if *spellIdsPath != "" && !*dryRun {
+		if saveErr := spellIds.Save(); saveErr != nil {
+			return errors.Join(err, saveErr)
+		}
+		fmt.Fprintf(os.Stderr, "Kept %d scaled spell ids in %s\n", spellIds.Len(), *spellIdsPath)
+	}
+
+	runReport.PrintSummary(os.Stderr)
+	if *reportPath != "" {
+		if writeErr := runReport.WriteFile(*reportPath); writeErr != nil {
+			return errors.Join(err, writeErr)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote the outcome of %d items to %s\n", len(runReport.Outcomes), *reportPath)
+	}
+
+	if revertScript != nil {
+		if writeErr := revertScript.WriteFile(*revertPath); writeErr != nil {
+			return errors.Join(err, writeErr)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote the revert script for %d rows to %s\n", revertScript.Count(), *revertPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	if spellDbc != nil {
+		if err := spellDbc.WriteFile(*spellDbcOut); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d scaled spells to %s\n", spellDbc.Count(), *spellDbcOut)
+	}
+	if itemDbc != nil {
+		if err := itemDbc.WriteFile(*itemDbcOut); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d generated items to %s\n", itemDbc.Count(), *itemDbcOut)
+	}
+	return nil
+}
+
+func Scale(highLevelItem mysql.DbItem, item *items.Item, itemLevel, quality int) error {
+	item.ApplyStats(items.ItemFromDbItem(highLevelItem))
+	if _, err := item.ScaleItem(itemLevel, quality); err != nil {
+		return err
+	}
+	slog.Debug("scaled item", logging.Entry, item.Entry, logging.Difficulty, item.Difficulty, logging.Stage, "scale", "name", item.Name, "itemLevel", itemLevel,
+		"stats", []int{*item.StatValue1, *item.StatValue2, *item.StatValue3, *item.StatValue4, *item.StatValue5, *item.StatValue6, *item.StatValue7, *item.StatValue8})
+	return nil
+}
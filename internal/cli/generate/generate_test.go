@@ -0,0 +1,203 @@
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/cli"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/dbtest"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlite"
+	"github.com/araxiaonline/endgame-item-generator/internal/items"
+	"github.com/araxiaonline/endgame-item-generator/internal/report"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// writeDb creates a sqlite file at path and runs setup in it
+func writeDb(t *testing.T, path string, setup []string) {
+	t.Helper()
+
+	client, err := sqlx.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	for _, sql := range setup {
+		if _, err := client.Exec(sql); err != nil {
+			t.Fatalf("setup failed %q: %v", sql, err)
+		}
+	}
+}
+
+// newTestDbs writes a world snapshot and items db with four source items:
+//
+//	100 a boss drop and 101 a trash drop from map 289
+//	102 a world drop
+//	103 a trash drop from a dungeon level no item rule covers
+//
+// The reference items 200-202 are quality 6 so they are not generated themselves.
+func newTestDbs(t *testing.T) (world string, itemsDb string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	world = filepath.Join(dir, "world.db")
+	writeDb(t, world, []string{
+		// -format insert reads every column a generated item sets, DbItem does not read these
+		dbtest.TableSql("item_template", mysql.DbItem{}, "SellPrice", "DisenchantID", "RequiredDisenchantSkill"),
+		dbtest.TableSql("spell_dbc", mysql.DbSpell{}),
+		"CREATE TABLE item_template_locale (ID, locale, Name, Description, VerifiedBuild)",
+		"INSERT INTO item_template (entry, name, Quality, class, subclass, ItemLevel, RequiredLevel, InventoryType, stat_type1, stat_value1, stat_type2, stat_value2, delay, dmg_min1, dmg_max1) VALUES (100, 'Blade of Testing', 3, 2, 7, 50, 45, 13, 4, 8, 7, 6, 2600, 40, 75)",
+		"INSERT INTO item_template (entry, name, Quality, class, subclass, ItemLevel, RequiredLevel, InventoryType, armor, stat_type1, stat_value1) VALUES (101, 'Helm of Testing', 3, 4, 4, 50, 45, 1, 500, 7, 12)",
+		"INSERT INTO item_template (entry, name, Quality, class, subclass, ItemLevel, RequiredLevel, InventoryType, armor, stat_type1, stat_value1) VALUES (102, 'Gloves of Testing', 3, 4, 2, 55, 50, 10, 90, 3, 9)",
+		"INSERT INTO item_template (entry, name, Quality, class, subclass, ItemLevel, RequiredLevel, InventoryType, armor, stat_type1, stat_value1) VALUES (103, 'Boots of Testing', 3, 4, 4, 60, 55, 8, 400, 4, 10)",
+		"INSERT INTO item_template (entry, name, Quality, class, subclass, ItemLevel, RequiredLevel, InventoryType, stat_type1, stat_value1, stat_type2, stat_value2, delay, dmg_min1, dmg_max1) VALUES (200, 'Reference Blade', 6, 2, 7, 245, 80, 13, 4, 60, 7, 50, 2600, 400, 750)",
+		"INSERT INTO item_template (entry, name, Quality, class, subclass, ItemLevel, RequiredLevel, InventoryType, armor, stat_type1, stat_value1, stat_type2, stat_value2) VALUES (201, 'Reference Helm', 6, 4, 4, 245, 80, 1, 2000, 7, 90, 4, 60)",
+		"INSERT INTO item_template (entry, name, Quality, class, subclass, ItemLevel, RequiredLevel, InventoryType, armor, stat_type1, stat_value1, stat_type2, stat_value2) VALUES (202, 'Reference Gloves', 6, 4, 2, 245, 80, 10, 400, 3, 70, 7, 50)",
+	})
+
+	itemsDb = filepath.Join(dir, "items.db")
+	writeDb(t, itemsDb, []string{
+		dbtest.TableSql("items", sqlite.HighLevelItem{}),
+		dbtest.TableSql("dungeon_items", sqlite.DungeonItem{}),
+		"INSERT INTO items (entry, class, name, Quality, itemLevel, subclass, stats_list) VALUES (200, 2, 'Reference Blade', 4, 245, 7, '4,7')",
+		"INSERT INTO items (entry, class, name, Quality, itemLevel, subclass, stats_list) VALUES (201, 4, 'Reference Helm', 4, 245, 4, '4,7')",
+		"INSERT INTO items (entry, class, name, Quality, itemLevel, subclass, stats_list) VALUES (202, 4, 'Reference Gloves', 4, 245, 2, '3,7')",
+		"INSERT INTO dungeon_items (entry, mapId, creatureId, Quality, expansion, dungeonLevel) VALUES (100, 289, 99901, 3, 0, 58)",
+		"INSERT INTO dungeon_items (entry, mapId, creatureId, Quality, expansion, dungeonLevel) VALUES (101, 289, 0, 3, 0, 58)",
+		"INSERT INTO dungeon_items (entry, mapId, creatureId, Quality, expansion, dungeonLevel) VALUES (103, 230, 0, 3, 0, 61)",
+	})
+
+	return world, itemsDb
+}
+
+// runGenerate runs the generate command on the test dbs with a fixed seed and returns what it
+// printed and its report
+func runGenerate(t *testing.T, world, itemsDb string, args ...string) ([]byte, report.Report) {
+	t.Helper()
+	defer slog.SetDefault(slog.Default())
+	stdout := os.Stdout
+	defer func() { os.Stdout = stdout }()
+
+	dir := t.TempDir()
+	out, reportPath := filepath.Join(dir, "out.sql"), filepath.Join(dir, "report.json")
+	global := []string{"-snapshot", world, "-items-db", itemsDb, "-log-level", "error", "generate", "-seed", "7", "-out", out, "-report", reportPath}
+
+	var stderr bytes.Buffer
+	if code := cli.Run(&stderr, append(global, args...), Command); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+
+	printed, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var runReport report.Report
+	if err := json.Unmarshal(data, &runReport); err != nil {
+		t.Fatal(err)
+	}
+	return printed, runReport
+}
+
+func TestGenerateOutcomes(t *testing.T) {
+	world, itemsDb := newTestDbs(t)
+
+	generated := func(reason string) report.Outcome {
+		return report.Outcome{Status: report.Generated, Reason: reason}
+	}
+	noRule := report.Outcome{Status: report.Skipped, Reason: report.NoItemRule}
+	all := map[int]report.Outcome{
+		100: generated(report.BossDrop),
+		101: generated(report.DungeonDrop),
+		102: generated(report.WorldDrop),
+		103: noRule,
+	}
+
+	// class and map narrow the world and items db queries, drop and name are checked per item
+	tests := []struct {
+		name     string
+		where    string
+		expected []int
+	}{
+		{"everything", "", []int{100, 101, 102, 103}},
+		{"item_template pushdown", "class=4", []int{101, 102, 103}},
+		{"dungeon_items pushdown", "map=289", []int{100, 101}},
+		{"dungeon_items pushdown without a match", "map=1", []int{}},
+		{"in memory", "drop=trash", []int{101, 103}},
+		{"pushdown and in memory", "class=4 and drop in (world, trash) and name != 'Boots of Testing'", []int{101, 102}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			printed, runReport := runGenerate(t, world, itemsDb, "-where", test.where)
+
+			entries := []int{}
+			for _, outcome := range runReport.Outcomes {
+				entries = append(entries, outcome.Entry)
+
+				expected := all[outcome.Entry]
+				if outcome.Status != expected.Status || outcome.Reason != expected.Reason {
+					t.Errorf("%d: expected %s %s, got %s %s", outcome.Entry, expected.Status, expected.Reason, outcome.Status, outcome.Reason)
+				}
+
+				// only generated items are in the sql
+				entry := []byte(strconv.Itoa(items.GeneratedEntry(outcome.Entry, 3)))
+				if found := bytes.Contains(printed, entry); found != (outcome.Status == report.Generated) {
+					t.Errorf("%d: generated entry %s in the sql is %v", outcome.Entry, entry, found)
+				}
+			}
+			if !reflect.DeepEqual(entries, test.expected) {
+				t.Errorf("expected the outcomes of %v, got %v", test.expected, entries)
+			}
+		})
+	}
+}
+
+func TestGenerateNoItemRule(t *testing.T) {
+	world, itemsDb := newTestDbs(t)
+
+	_, runReport := runGenerate(t, world, itemsDb, "-where", "entry=103")
+	if len(runReport.Outcomes) != 1 {
+		t.Fatalf("expected one outcome, got %+v", runReport.Outcomes)
+	}
+
+	outcome := runReport.Outcomes[0]
+	expected := report.Outcome{
+		Entry:  103,
+		Name:   "Boots of Testing",
+		Status: report.Skipped,
+		Reason: report.NoItemRule,
+		Detail: "trash drop from a level 61 expansion 0 dungeon on difficulty 3",
+		Map:    230,
+	}
+	if outcome != expected {
+		t.Errorf("expected %+v, got %+v", expected, outcome)
+	}
+}
+
+func TestGenerateWorkersSameOutput(t *testing.T) {
+	world, itemsDb := newTestDbs(t)
+
+	for _, format := range []string{"copy", "insert", "json"} {
+		t.Run(format, func(t *testing.T) {
+			one, _ := runGenerate(t, world, itemsDb, "-format", format, "-workers", "1")
+			eight, _ := runGenerate(t, world, itemsDb, "-format", format, "-workers", "8")
+			if len(one) == 0 {
+				t.Fatal("nothing was printed")
+			}
+			if !bytes.Equal(one, eight) {
+				t.Errorf("-workers 8 printed something else than -workers 1\n%s\n---\n%s", one, eight)
+			}
+		})
+	}
+}
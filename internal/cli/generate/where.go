@@ -0,0 +1,93 @@
+package generate
+
+import (
+	"sort"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/config"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlite"
+	"github.com/araxiaonline/endgame-item-generator/internal/filter"
+	"github.com/araxiaonline/endgame-item-generator/internal/items"
+	"github.com/araxiaonline/endgame-item-generator/internal/report"
+)
+
+// whereFields are what -where can compare. The item_template ones go into the item query, the
+// dungeon_items ones pick the entries from the items db first, role and drop are worked out per item.
+var whereFields = filter.Fields{
+	"entry":        {Kind: filter.Int, Table: "item_template", Column: "entry"},
+	"name":         {Kind: filter.String},
+	"class":        {Kind: filter.Int, Table: "item_template", Column: "class"},
+	"subclass":     {Kind: filter.Int, Table: "item_template", Column: "subclass"},
+	"quality":      {Kind: filter.Int, Table: "item_template", Column: "Quality"},
+	"ilvl":         {Kind: filter.Int, Table: "item_template", Column: "ItemLevel"},
+	"reqlevel":     {Kind: filter.Int, Table: "item_template", Column: "RequiredLevel"},
+	"slot":         {Kind: filter.Int, Table: "item_template", Column: "InventoryType"},
+	"map":          {Kind: filter.Int, Table: "dungeon_items", Column: "mapId"},
+	"expansion":    {Kind: filter.Int, Table: "dungeon_items", Column: "expansion"},
+	"dungeonlevel": {Kind: filter.Int, Table: "dungeon_items", Column: "dungeonLevel"},
+	"boss":         {Kind: filter.Int, Table: "dungeon_items", Column: "creatureId"},
+	"drop":         {Kind: filter.String, Words: config.DropKinds},
+	"role":         {Kind: filter.String, Words: roles()},
+}
+
+// the report reason of each kind of drop
+var dropReasons = map[string]string{
+	config.WorldDrop:     report.WorldDrop,
+	config.TrashDrop:     report.DungeonDrop,
+	config.BossDrop:      report.BossDrop,
+	config.FinalBossDrop: report.FinalBoss,
+}
+
+// notInDungeon are the dungeon_items values of an item that is not from a dungeon
+var notInDungeon = filter.Values{"map": 0, "expansion": 0, "dungeonlevel": 0, "boss": 0}
+
+func roles() []string {
+	names := []string{}
+	for _, name := range items.ClassUserTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// dropOf is where the item the dungeon lookup found drops, an item that is not in dungeon_items is a world drop
+func dropOf(lookup sqlite.DungeonItem, difficulty int) config.Drop {
+	drop := config.Drop{Kind: config.WorldDrop, Difficulty: difficulty}
+	if lookup.Entry == 0 {
+		return drop
+	}
+
+	drop.Kind, drop.Expansion, drop.DungeonLevel = config.TrashDrop, lookup.Expansion, lookup.DungeonLevel
+	if mysql.IsFinalBoss(lookup.CreatureId) {
+		drop.Kind = config.FinalBossDrop
+	} else if lookup.CreatureId != 0 {
+		drop.Kind = config.BossDrop
+	}
+	return drop
+}
+
+// whereValues are the -where fields of a source item
+func whereValues(item *items.Item, lookup sqlite.DungeonItem, drop config.Drop) filter.Values {
+	value := func(p *int) int {
+		if p == nil {
+			return 0
+		}
+		return *p
+	}
+	return filter.Values{
+		"entry":        item.Entry,
+		"name":         item.Name,
+		"class":        value(item.Class),
+		"subclass":     value(item.Subclass),
+		"quality":      value(item.Quality),
+		"ilvl":         value(item.ItemLevel),
+		"reqlevel":     value(item.RequiredLevel),
+		"slot":         value(item.InventoryType),
+		"map":          lookup.MapId,
+		"expansion":    lookup.Expansion,
+		"dungeonlevel": lookup.DungeonLevel,
+		"boss":         lookup.CreatureId,
+		"drop":         drop.Kind,
+		"role":         items.ClassUserTypes[item.GetClassUserType()],
+	}
+}
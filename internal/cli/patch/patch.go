@@ -0,0 +1,136 @@
+package patch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/cli"
+	"github.com/araxiaonline/endgame-item-generator/internal/dbc"
+	"github.com/araxiaonline/endgame-item-generator/internal/mpq"
+)
+
+var Command = cli.Command{
+	Name:    "patch",
+	Summary: "pack the generated dbc files into a client patch MPQ",
+	Run:     run,
+}
+
+// Packs the generated DBC files into a client patch MPQ under DBFilesClient\ and prints what changed
+// compared to the client's own copies. Any extra .dbc files (like the one the durability command writes)
+// can be passed as arguments.
+//
+//	item-gen patch -orig ~/wow/dbc -out patch-4.MPQ cmd/durability-costs/DurabilityCosts.dbc
+func run(g *cli.Globals, args []string) error {
+	fs := g.FlagSet("patch", "[extra .dbc files]", "path of the MPQ archive to write", "patch-4.MPQ")
+	dbcDir := fs.String("dbc", "DBFilesClient", "directory of generated .dbc files to pack, skipped if it does not exist")
+	origDir := fs.String("orig", "", "directory of the unmodified client .dbc files, used for the change manifest")
+	compress := fs.Bool("compress", true, "zlib compress the files in the archive")
+	if err := g.Parse(fs, args, false); err != nil {
+		return err
+	}
+	out := g.Out
+
+	paths, err := gatherDbcs(*dbcDir, fs.Args())
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no .dbc files found in %s or the arguments", *dbcDir)
+	}
+
+	archive := mpq.NewWriter(*compress)
+
+	fmt.Println("Patch manifest")
+	for _, name := range sortedKeys(paths) {
+		path := paths[name]
+
+		patched, err := dbc.Open(path)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid dbc: %v", path, err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		archivePath := "DBFilesClient\\" + name
+		archive.Add(archivePath, data)
+
+		fmt.Printf("\n%s (%d records) from %s\n", archivePath, len(patched.Records), path)
+		if *origDir == "" {
+			continue
+		}
+
+		origPath := filepath.Join(*origDir, name)
+		if _, err := os.Stat(origPath); os.IsNotExist(err) {
+			fmt.Println("  new file, not in the client dbc directory")
+			continue
+		}
+
+		orig, err := dbc.Open(origPath)
+		if err != nil {
+			return fmt.Errorf("%s is not a valid dbc: %v", origPath, err)
+		}
+
+		diff, err := dbc.Compare(orig, patched)
+		if err != nil {
+			return fmt.Errorf("failed to compare %s: %v", name, err)
+		}
+
+		printIds("added", diff.Added)
+		printIds("changed", diff.Changed)
+		printIds("removed", diff.Removed)
+	}
+
+	if err := archive.WriteFile(out); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nWrote %d files to %s\n", len(paths), out)
+	return nil
+}
+
+// every .dbc in dir plus the extra files, keyed by file name so an extra file replaces one from dir
+func gatherDbcs(dir string, extra []string) (map[string]string, error) {
+	paths := map[string]string{}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %v", dir, err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".dbc") {
+			paths[entry.Name()] = filepath.Join(dir, entry.Name())
+		}
+	}
+
+	for _, path := range extra {
+		if !strings.EqualFold(filepath.Ext(path), ".dbc") {
+			return nil, fmt.Errorf("%s is not a .dbc file", path)
+		}
+		paths[filepath.Base(path)] = path
+	}
+
+	return paths, nil
+}
+
+func printIds(label string, ids []uint32) {
+	if len(ids) == 0 {
+		fmt.Printf("  %-8s 0\n", label)
+		return
+	}
+	fmt.Printf("  %-8s %d: %s\n", label, len(ids), dbc.FormatIds(ids))
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,23 +1,36 @@
-package main
+package raid
 
 import (
-	"flag"
+	"errors"
 	"fmt"
-	"io"
-	"log"
-	"math/rand"
+	"log/slog"
 	"os"
+	"sort"
 	"strings"
-	"time"
 
+	"github.com/araxiaonline/endgame-item-generator/internal/cli"
 	"github.com/araxiaonline/endgame-item-generator/internal/config"
 	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlrow"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/store"
+	"github.com/araxiaonline/endgame-item-generator/internal/export"
+	"github.com/araxiaonline/endgame-item-generator/internal/ids"
 	"github.com/araxiaonline/endgame-item-generator/internal/items"
+	"github.com/araxiaonline/endgame-item-generator/internal/locale"
+	"github.com/araxiaonline/endgame-item-generator/internal/logging"
+	"github.com/araxiaonline/endgame-item-generator/internal/naming"
+	"github.com/araxiaonline/endgame-item-generator/internal/rng"
+	"github.com/araxiaonline/endgame-item-generator/internal/spells"
 
 	_ "github.com/go-sql-driver/mysql"
-	"github.com/joho/godotenv"
 )
 
+var Command = cli.Command{
+	Name:    "raid",
+	Summary: "generate the Molten Core raid gear",
+	Run:     run,
+}
+
 // Molten Core Configuration
 const (
 	MOLTEN_CORE_MAP_ID     = 409
@@ -51,13 +64,6 @@ var tankTrinketSpells = []int{
 	60258,
 }
 
-// Fire-themed item names for Molten Core fire resistance
-var fireThemeNames = []string{
-	"flame", "fire", "salamander", "crimson", "burning",
-	"blazing", "infernal", "molten", "ember", "igniting",
-	"flamewalker", "flameguard",
-}
-
 // Stat priority mappings based on class types
 type StatPriority struct {
 	Primary   []int // Must have stats
@@ -125,7 +131,7 @@ type SpellDetails struct {
 
 // MoltenCoreGenerator handles Molten Core item generation
 type MoltenCoreGenerator struct {
-	db        *mysql.MySqlDb
+	db        store.Store
 	debug     bool
 	itemLevel int
 	quality   int
@@ -250,7 +256,7 @@ func (g *MoltenCoreGenerator) addMissingKeyStats(item *items.Item, classType int
 
 		if statType, err := item.GetField(statTypeField); err == nil && statType == 0 {
 			// Found empty slot, calculate appropriate stat value (reduced by 50% to prevent over-correction)
-			baseValue := rand.Intn(76) + 175 // Random between 175-250 (50% of original 350-500)
+			baseValue := rng.IntN(76) + 175 // Random between 175-250 (50% of original 350-500)
 
 			// Apply scaling based on item level and quality
 			scaledValue := g.calculateScaledStatValue(baseValue, targetStatType)
@@ -261,9 +267,8 @@ func (g *MoltenCoreGenerator) addMissingKeyStats(item *items.Item, classType int
 
 			addedStats = append(addedStats, fmt.Sprintf("%s: %d", statName, scaledValue))
 
-			if g.debug {
-				log.Printf("Auto-added missing key stat %s (%d) = %d to %s", statName, targetStatType, scaledValue, item.Name)
-			}
+			slog.Debug("auto-added a missing key stat", logging.Entry, item.Entry, logging.Stage, "stats",
+				"name", item.Name, "stat", statName, "statType", targetStatType, "value", scaledValue)
 
 			return true
 		}
@@ -319,6 +324,37 @@ func getSimilarArmorSubclasses(originalSubclass int) []int {
 	return []int{} // No alternatives found
 }
 
+// getEquivalentInventoryTypes returns inventory types that fill the same slot for fallback searches
+func getEquivalentInventoryTypes(inventoryType *int) []int {
+	if inventoryType == nil {
+		return []int{}
+	}
+
+	inventoryGroups := map[int][]int{
+		5:  {5, 20},      // Chest -> Chest, Robe
+		20: {5, 20},      // Robe -> Chest, Robe
+		13: {13, 21, 22}, // One-Hand -> One-Hand, Main Hand, Off Hand
+		21: {13, 21},     // Main Hand -> One-Hand, Main Hand
+		22: {13, 22},     // Off Hand -> One-Hand, Off Hand
+		15: {15, 25, 26}, // Ranged -> Ranged, Thrown, Ranged Right
+		25: {15, 25, 26}, // Thrown -> Ranged, Thrown, Ranged Right
+		26: {15, 25, 26}, // Ranged Right -> Ranged, Thrown, Ranged Right
+	}
+
+	if alternatives, exists := inventoryGroups[*inventoryType]; exists {
+		// Return alternatives excluding the original inventory type
+		var result []int
+		for _, alt := range alternatives {
+			if alt != *inventoryType {
+				result = append(result, alt)
+			}
+		}
+		return result
+	}
+
+	return []int{} // No alternatives found
+}
+
 // getSpellDetails looks up spell information from the database
 func (g *MoltenCoreGenerator) getSpellDetails(spellID int) *SpellDetails {
 	if spellID == 0 {
@@ -327,7 +363,7 @@ func (g *MoltenCoreGenerator) getSpellDetails(spellID int) *SpellDetails {
 
 	spell, err := g.db.GetSpell(spellID)
 	if err != nil {
-		log.Printf("Failed to lookup spell %d: %v", spellID, err)
+		slog.Warn("failed to look up the spell", logging.Spell, spellID, logging.Stage, "spells", "err", err)
 		return nil
 	}
 
@@ -338,7 +374,7 @@ func (g *MoltenCoreGenerator) getSpellDetails(spellID int) *SpellDetails {
 	}
 }
 
-func NewMoltenCoreGenerator(db *mysql.MySqlDb, debug bool) *MoltenCoreGenerator {
+func NewMoltenCoreGenerator(db store.Store, debug bool) *MoltenCoreGenerator {
 	return &MoltenCoreGenerator{
 		db:        db,
 		debug:     debug,
@@ -462,14 +498,14 @@ func (g *MoltenCoreGenerator) generateClassAppropriateStats(item *items.Item, cl
 			// Add 2 random secondary stats
 			secondaryStats := []int{18, 27, 15} // Crit Rating, Haste Rating, Hit Rating
 			for i := 0; i < 2 && statSlot <= 7; i++ {
-				statType := secondaryStats[rand.Intn(len(secondaryStats))]
+				statType := secondaryStats[rng.IntN(len(secondaryStats))]
 				statSlot = g.setItemStat(item, statSlot, statType, baseStatValue)
 			}
 		} else {
 			// Armor: Add more secondary stats
 			secondaryStats := []int{18, 27, 15, 43} // Crit, Haste, Hit, Armor Pen
 			for i := 0; i < 3 && statSlot <= 7; i++ {
-				statType := secondaryStats[rand.Intn(len(secondaryStats))]
+				statType := secondaryStats[rng.IntN(len(secondaryStats))]
 				statSlot = g.setItemStat(item, statSlot, statType, baseStatValue)
 			}
 		}
@@ -484,14 +520,14 @@ func (g *MoltenCoreGenerator) generateClassAppropriateStats(item *items.Item, cl
 			statSlot = g.setItemStat(item, statSlot, 38, highStatValue*2) // Attack Power
 			secondaryStats := []int{19, 28, 16} // Crit Ranged, Haste Ranged, Hit Ranged
 			for i := 0; i < 2 && statSlot <= 7; i++ {
-				statType := secondaryStats[rand.Intn(len(secondaryStats))]
+				statType := secondaryStats[rng.IntN(len(secondaryStats))]
 				statSlot = g.setItemStat(item, statSlot, statType, baseStatValue)
 			}
 		} else {
 			// Armor: Add more secondary stats
 			secondaryStats := []int{19, 28, 16, 43} // Crit Ranged, Haste Ranged, Hit Ranged, Armor Pen
 			for i := 0; i < 3 && statSlot <= 7; i++ {
-				statType := secondaryStats[rand.Intn(len(secondaryStats))]
+				statType := secondaryStats[rng.IntN(len(secondaryStats))]
 				statSlot = g.setItemStat(item, statSlot, statType, baseStatValue)
 			}
 		}
@@ -506,14 +542,14 @@ func (g *MoltenCoreGenerator) generateClassAppropriateStats(item *items.Item, cl
 			statSlot = g.setItemStat(item, statSlot, 39, highStatValue*2) // Ranged Attack Power
 			secondaryStats := []int{19, 28, 16} // Crit Ranged, Haste Ranged, Hit Ranged
 			for i := 0; i < 2 && statSlot <= 7; i++ {
-				statType := secondaryStats[rand.Intn(len(secondaryStats))]
+				statType := secondaryStats[rng.IntN(len(secondaryStats))]
 				statSlot = g.setItemStat(item, statSlot, statType, baseStatValue)
 			}
 		} else {
 			// Armor: Add more secondary stats
 			secondaryStats := []int{19, 28, 16, 43} // Crit Ranged, Haste Ranged, Hit Ranged, Armor Pen
 			for i := 0; i < 3 && statSlot <= 7; i++ {
-				statType := secondaryStats[rand.Intn(len(secondaryStats))]
+				statType := secondaryStats[rng.IntN(len(secondaryStats))]
 				statSlot = g.setItemStat(item, statSlot, statType, baseStatValue)
 			}
 		}
@@ -528,14 +564,14 @@ func (g *MoltenCoreGenerator) generateClassAppropriateStats(item *items.Item, cl
 			statSlot = g.setItemStat(item, statSlot, 45, highStatValue*2) // Spell Power
 			secondaryStats := []int{20, 29, 17} // Crit Spell, Haste Spell, Hit Spell
 			for i := 0; i < 2 && statSlot <= 7; i++ {
-				statType := secondaryStats[rand.Intn(len(secondaryStats))]
+				statType := secondaryStats[rng.IntN(len(secondaryStats))]
 				statSlot = g.setItemStat(item, statSlot, statType, baseStatValue)
 			}
 		} else {
 			// Armor: Add more secondary stats
 			secondaryStats := []int{20, 29, 17, 46} // Crit Spell, Haste Spell, Hit Spell, Spell Penetration
 			for i := 0; i < 3 && statSlot <= 7; i++ {
-				statType := secondaryStats[rand.Intn(len(secondaryStats))]
+				statType := secondaryStats[rng.IntN(len(secondaryStats))]
 				statSlot = g.setItemStat(item, statSlot, statType, baseStatValue)
 			}
 		}
@@ -551,14 +587,14 @@ func (g *MoltenCoreGenerator) generateClassAppropriateStats(item *items.Item, cl
 			statSlot = g.setItemStat(item, statSlot, 45, highStatValue*2) // Spell Power
 			secondaryStats := []int{42, 29} // Mana Regen, Haste Spell
 			if statSlot <= 7 {
-				statType := secondaryStats[rand.Intn(len(secondaryStats))]
+				statType := secondaryStats[rng.IntN(len(secondaryStats))]
 				statSlot = g.setItemStat(item, statSlot, statType, baseStatValue)
 			}
 		} else {
 			// Armor: Add more secondary stats
 			secondaryStats := []int{42, 29, 40} // Mana Regen, Haste Spell, Spell Healing
 			for i := 0; i < 2 && statSlot <= 7; i++ {
-				statType := secondaryStats[rand.Intn(len(secondaryStats))]
+				statType := secondaryStats[rng.IntN(len(secondaryStats))]
 				statSlot = g.setItemStat(item, statSlot, statType, baseStatValue)
 			}
 		}
@@ -573,14 +609,14 @@ func (g *MoltenCoreGenerator) generateClassAppropriateStats(item *items.Item, cl
 			statSlot = g.setItemStat(item, statSlot, 12, baseStatValue)  // Defense Rating
 			secondaryStats := []int{14, 13, 47} // Parry, Dodge, Block Value
 			for i := 0; i < 2 && statSlot <= 7; i++ {
-				statType := secondaryStats[rand.Intn(len(secondaryStats))]
+				statType := secondaryStats[rng.IntN(len(secondaryStats))]
 				statSlot = g.setItemStat(item, statSlot, statType, baseStatValue)
 			}
 		} else {
 			// Armor: Add more defensive stats
 			secondaryStats := []int{12, 14, 13, 47} // Defense, Parry, Dodge, Block Value
 			for i := 0; i < 3 && statSlot <= 7; i++ {
-				statType := secondaryStats[rand.Intn(len(secondaryStats))]
+				statType := secondaryStats[rng.IntN(len(secondaryStats))]
 				statSlot = g.setItemStat(item, statSlot, statType, baseStatValue)
 			}
 		}
@@ -674,7 +710,7 @@ func (g *MoltenCoreGenerator) calculateBaseStatValue() int {
 	
 	// Add some randomness (±10%)
 	variation := int(float64(baseValue) * 0.1)
-	baseValue += rand.Intn(variation*2) - variation
+	baseValue += rng.IntN(variation*2) - variation
 	
 	// Ensure minimum value
 	if baseValue < 10 {
@@ -684,47 +720,133 @@ func (g *MoltenCoreGenerator) calculateBaseStatValue() int {
 	return baseValue
 }
 
-func main() {
-	rand.Seed(time.Now().UnixNano())
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
-	godotenv.Load("../../.env")
+// Generates the Molten Core raid gear, see MoltenCoreGenerator
+//
+//	item-gen raid -sql -format insert -out gear.sql
+func run(g *cli.Globals, args []string) error {
+	fs := g.FlagSet("raid", "", "", "")
+	outputSql := fs.Bool("sql", false, "Output SQL statements for generated items")
+	validateOnly := fs.Bool("validate", false, "Only validate items without generating")
+	scalerName := fs.String("scaler", items.DefaultScaler, fmt.Sprintf("Stat scaling formula, one of %s", strings.Join(items.ScalerNames(), ", ")))
+	seed := fs.Uint64("seed", 0, "Seed for every random choice, the same seed gives the same items. 0 picks one from the clock")
+	format := fs.String("format", "copy", "Output for -sql: copy (copies the source rows and updates them), insert (complete rows like gear.manual.sql), json (written to -json-out) or csv (written to -csv-out)")
+	jsonOut := fs.String("json-out", "raid-gear.json", "Where -format json writes the generated items")
+	csvOut := fs.String("csv-out", "raid-gear.csv", "Where -format csv writes the generated items in the emblem importer layout")
+	batchSize := fs.Int("batch", 100, "Rows per INSERT with -format insert")
+	spellIdsPath := fs.String("spell-ids", "", "File that keeps the id every scaled spell variant was given, empty keeps them for this run only")
+	localesPath := fs.String("locales", "", "Word lists for the translated item names, defaults to the built in lists, none to skip the translations")
+	preloadItems := fs.Bool("preload-items", false, "Read all of item_template and its spells up front instead of one query per item")
+	namesPath := fs.String("names", "", "Word pools the generated item names are picked from, defaults to the built in pools")
+	if err := g.Parse(fs, args, true); err != nil {
+		return err
+	}
+
+	profile, err := config.LoadProfile(g.Profile)
+	if err != nil {
+		return err
+	}
+	config.Use(profile)
 
-	debug := flag.Bool("debug", false, "Enable verbose logging inside generator")
-	outputSql := flag.Bool("sql", false, "Output SQL statements for generated items")
-	validateOnly := flag.Bool("validate", false, "Only validate items without generating")
-	flag.Parse()
+	words := locale.Words{}
+	if *localesPath != "none" {
+		words, err = locale.Load(*localesPath)
+		if err != nil {
+			return err
+		}
+	}
+	locale.Use(words)
 
-	if *debug {
-		log.SetOutput(os.Stdout)
-	} else {
-		log.SetOutput(io.Discard)
+	names, err := naming.Load(*namesPath)
+	if err != nil {
+		return err
 	}
 
-	// Connect to MySQL
-	mysqlDb, err := mysql.Connect(&mysql.MySqlConfig{
-		Host:     os.Getenv("DB_HOST"),
-		User:     os.Getenv("DB_USER"),
-		Password: os.Getenv("DB_PASSWORD"),
-		Database: os.Getenv("DB_NAME"),
-	})
+	// raid items and spells get their own id range so they never land on the dungeon generator's rows
+	ids.Use(ids.RaidGear)
 
+	spellIds := ids.NewMap("")
+	if *spellIdsPath != "" {
+		spellIds, err = ids.LoadMap(*spellIdsPath)
+		if err != nil {
+			return err
+		}
+	}
+	spells.UseIdMap(spellIds)
+
+	if err := items.UseScaler(*scalerName); err != nil {
+		return err
+	}
+
+	if *seed != 0 {
+		rng.Seed(*seed)
+	}
+
+	// Connect to MySQL or the local world snapshot
+	worldDb, err := store.Open(g.Snapshot)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer worldDb.Close()
+
+	// every reference candidate is scaled to pick the best one, the cache reads each class and subclass
+	// and each spell once
+	cache := store.NewCache(worldDb)
+	store.Use(cache)
+	if *preloadItems {
+		if err := cache.LoadItems(); err != nil {
+			return err
+		}
+	}
+
+	// the taken names, id checks and -format insert rows are read straight from the world database
+	querier, ok := worldDb.(sqlrow.Querier)
+	if !ok {
+		return errors.New("the world database can not be read directly")
+	}
+
+	// generated names have to differ from every item already in item_template
+	namer := naming.NewNamer(names)
+	if err := namer.LoadTaken(querier); err != nil {
+		return err
+	}
+	naming.Use(namer)
+
+	// -format insert collects the items as full rows and prints them once every item is generated
+	var spellRows, itemRows, itemLocaleRows *sqlrow.Batch
+	rowSql := new(strings.Builder)
+	switch *format {
+	case "copy", "json", "csv":
+	case "insert":
+		spellRows = sqlrow.NewBatch(rowSql, "`spell_dbc`", "ID", *batchSize)
+		itemRows = sqlrow.NewBatch(rowSql, "`item_template`", "entry", *batchSize)
+		itemLocaleRows = sqlrow.NewBatch(rowSql, "`item_template_locale`", "ID", *batchSize)
+		itemLocaleRows.Unique = []string{"locale"}
+	default:
+		return fmt.Errorf("unknown -format %s, use copy, insert, json or csv", *format)
+	}
+
+	var document *export.Document
+	if *format == "json" || *format == "csv" {
+		document = export.NewDocument("raid-gear", *scalerName)
 	}
 
 	// Initialize Molten Core generator
-	generator := NewMoltenCoreGenerator(mysqlDb, *debug)
+	generator := NewMoltenCoreGenerator(cache, g.Debug || g.LogLevel == "debug")
 
 	fmt.Printf("🔥 Molten Core Item Generator v2.0\n")
+	fmt.Printf("Seed: %d\n", rng.CurrentSeed())
 	fmt.Printf("Target Item Level: %d, Quality: %d (Epic)\n\n", MOLTEN_CORE_ITEM_LEVEL, MOLTEN_CORE_QUALITY)
 
 	// Get Molten Core items from database
 	// Boss entries and GameObject entries for Molten Core
 	bossEntries := []int{11502}        // Previously hardcoded boss entry
 	gameObjectEntries := []int{179703} // Previously hardcoded GameObject entry
-	rareItems, err := mysqlDb.GetBossMapItems(MOLTEN_CORE_MAP_ID, bossEntries, gameObjectEntries, 0, 0)
+	rareItems, err := worldDb.GetBossMapItems(MOLTEN_CORE_MAP_ID, bossEntries, gameObjectEntries, 0, 0)
 	if err != nil {
-		log.Fatal("Failed to get Molten Core items:", err)
+		return fmt.Errorf("failed to get Molten Core items: %w", err)
+	}
+	if err := cache.LoadSpellsOf(rareItems); err != nil {
+		return err
 	}
 
 	fmt.Printf("📋 Processing %d Molten Core items...\n\n", len(rareItems))
@@ -774,8 +896,23 @@ func main() {
 			}
 
 			if *outputSql && result.Item != nil {
-				sqlStatement := items.ItemToSql(*result.Item, 80, MOLTEN_CORE_DIFFICULTY)
-				fmt.Printf("SQL: %s\n", sqlStatement)
+				if err := checkIds(querier, result.Item); err != nil {
+					return err
+				}
+				if document != nil {
+					var ref *export.Reference
+					if result.ReferenceItem != nil {
+						ref = export.NewReference("GetRaidPhase1Items", result.ReferenceItem.DbItem)
+					}
+					document.Add(export.NewRecord(*result.Item, 80, MOLTEN_CORE_DIFFICULTY, ref))
+				} else if itemRows != nil {
+					if err := addRows(querier, spellRows, itemRows, itemLocaleRows, result.Item); err != nil {
+						return err
+					}
+				} else {
+					sqlStatement := items.ItemToSql(*result.Item, 80, MOLTEN_CORE_DIFFICULTY)
+					fmt.Printf("SQL: %s\n", sqlStatement)
+				}
 			}
 		} else {
 			fmt.Printf("❌ Failed to generate %s\n", dbItem.Name)
@@ -790,12 +927,88 @@ func main() {
 		fmt.Println()
 	}
 
+	if itemRows != nil && *outputSql {
+		if err := spellRows.Flush(); err != nil {
+			return err
+		}
+		if err := itemRows.Flush(); err != nil {
+			return err
+		}
+		if err := itemLocaleRows.Flush(); err != nil {
+			return err
+		}
+		fmt.Printf("SQL:\n%s", rowSql)
+	}
+
+	if document != nil && *outputSql {
+		path, write := *jsonOut, document.WriteJson
+		if *format == "csv" {
+			path, write = *csvOut, document.WriteCsv
+		}
+		file, err := os.Create(path)
+		if err == nil {
+			err = write(file)
+			file.Close()
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Wrote %d items to %s\n", len(document.Items), path)
+	}
+
+	if *spellIdsPath != "" && *outputSql {
+		if err := spellIds.Save(); err != nil {
+			return err
+		}
+	}
+
 	// Print summary
 	fmt.Printf("\n🏆 Generation Summary:\n")
 	fmt.Printf("Total Items: %d\n", len(rareItems))
 	fmt.Printf("Successful: %d\n", successCount)
 	fmt.Printf("Failed: %d\n", len(rareItems)-successCount)
 	fmt.Printf("Success Rate: %.1f%%\n", float64(successCount)/float64(len(rareItems))*100)
+	return nil
+}
+
+// checkIds picks the ids of the item's scaled spells and fails when the item or one of its spells
+// would be written over a row from somewhere else
+func checkIds(querier sqlrow.Querier, item *items.Item) error {
+	if err := item.AllocateSpellIds(); err != nil {
+		return err
+	}
+	for _, spell := range item.Spells {
+		if err := ids.Check(querier, ids.Spells, spell.ID, spell.WriteId(*item.Quality)); err != nil {
+			return err
+		}
+	}
+	return ids.Check(querier, ids.Items, item.Entry, items.GeneratedEntry(item.Entry, MOLTEN_CORE_DIFFICULTY))
+}
+
+// addRows queues the item, its translated names and its scaled spells as full rows for -format insert
+func addRows(db sqlrow.Querier, spellRows, itemRows, itemLocaleRows *sqlrow.Batch, item *items.Item) error {
+	for _, spell := range item.Spells {
+		row, err := spells.SpellRow(db, spell, *item.Quality)
+		if err != nil {
+			return err
+		}
+		if err := spellRows.Add(row); err != nil {
+			return err
+		}
+	}
+	row, localeRows, err := items.ItemRow(db, *item, 80, MOLTEN_CORE_DIFFICULTY)
+	if err != nil {
+		return err
+	}
+	if err := itemRows.Add(row); err != nil {
+		return err
+	}
+	for _, row := range localeRows {
+		if err := itemLocaleRows.Add(row); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // addFireResistanceIfNeeded scales existing fire resistance by 1.5x and adds fire resistance to fire-themed items
@@ -813,20 +1026,9 @@ func addFireResistanceIfNeeded(item *items.Item) {
 		return // Don't add additional fire resistance if it already exists
 	}
 
-	itemName := strings.ToLower(item.Name)
-
-	// Check for fire-related keywords in the item name
-	fireKeywords := []string{"flame", "fire", "salamander", "crimson", "burning", "blazing", "infernal", "molten", "ember", "igniting", "flamewalker", "flameguard"}
-
-	hasFireName := false
-	for _, keyword := range fireKeywords {
-		if strings.Contains(itemName, keyword) {
-			hasFireName = true
-			break
-		}
-	}
-
-	if !hasFireName {
+	// Check for fire-related keywords in the item name, they are the keywords of the Molten Core name theme
+	theme, _ := naming.Current().Config().Theme(MOLTEN_CORE_MAP_ID)
+	if !theme.Matches(item.Name) {
 		return
 	}
 
@@ -990,10 +1192,21 @@ func getStatValue(item *items.Item, index int) *int {
 	}
 }
 
+// stat types in id order, map order changes every run and would shuffle stat slots and output
+func sortedStatTypes[V any](stats map[int]V) []int {
+	statTypes := make([]int, 0, len(stats))
+	for statType := range stats {
+		statTypes = append(statTypes, statType)
+	}
+	sort.Ints(statTypes)
+	return statTypes
+}
+
 // Helper function to write stats back to item
 func writeStatsToItem(item *items.Item, stats map[int]int) {
 	i := 1
-	for statType, statValue := range stats {
+	for _, statType := range sortedStatTypes(stats) {
+		statValue := stats[statType]
 		if i > 8 {
 			break
 		}
@@ -1044,16 +1257,16 @@ func (g *MoltenCoreGenerator) GenerateItem(dbItem mysql.DbItem, validateOnly boo
 	// Create item from database item
 	item := items.ItemFromDbItem(dbItem)
 	item.SetDifficulty(MOLTEN_CORE_DIFFICULTY)
+	item.SetMap(MOLTEN_CORE_MAP_ID)
 	item.ApplyTierModifiers(MOLTEN_CORE_PHASE)
 
 	// Initial scaling
 	item.ScaleItem(g.itemLevel, g.quality)
 
 	classType := item.GetClassUserType()
-	if g.debug {
-		log.Printf("Item: %s (Entry: %d) - Class: %d, Subclass: %d, ClassType: %s",
-			item.Name, item.Entry, *item.Class, *item.Subclass, getClassString(classType))
-	}
+	logger := slog.With(logging.Entry, item.Entry, logging.Difficulty, MOLTEN_CORE_DIFFICULTY)
+	logger.Debug("raid item", logging.Stage, "lookup", "name", item.Name, "class", *item.Class, "subclass", *item.Subclass,
+		"classType", getClassString(classType))
 
 	// Handle subclass mapping for weapons
 	subclassToUse := *item.Subclass
@@ -1086,32 +1299,28 @@ func (g *MoltenCoreGenerator) GenerateItem(dbItem mysql.DbItem, validateOnly boo
 		}
 	}
 
-	if g.debug {
-		log.Printf("Found %d compatible reference items for class type %s",
-			len(compatibleChoices), getClassString(classType))
-		
-		// If no compatible items found, show diagnostic info
-		if len(compatibleChoices) == 0 {
-			log.Printf("[DIAGNOSTIC] Total items returned from DB: %d", len(highLevelItems))
-			log.Printf("[DIAGNOSTIC] Target item: %s - Class: %d, Subclass: %d, InventoryType: %v, ClassType: %s", 
-				item.Name, *item.Class, *item.Subclass, getInventoryTypeString(item.InventoryType), getClassString(classType))
-			
-			// Show details of items that were considered but rejected
-			for i, highLevelItem := range highLevelItems {
-				if i >= 5 { // Limit to first 5 items to avoid spam
-					log.Printf("[DIAGNOSTIC] ... and %d more items", len(highLevelItems)-5)
-					break
-				}
-				highLevelItem := items.ItemFromDbItem(highLevelItem)
-				highLevelItem.ScaleItem(*highLevelItem.ItemLevel, *item.Quality)
-				highClassType := highLevelItem.GetClassUserType()
-				
-				log.Printf("[DIAGNOSTIC] Rejected: %s - Class: %d, Subclass: %d, InventoryType: %v, ClassType: %s (ClassMatch: %v, InvTypeMatch: %v)",
-					highLevelItem.Name, *highLevelItem.Class, *highLevelItem.Subclass, 
-					getInventoryTypeString(highLevelItem.InventoryType), getClassString(highClassType),
-					highClassType == classType,
-					(item.InventoryType != nil && highLevelItem.InventoryType != nil && *item.InventoryType == *highLevelItem.InventoryType))
+	logger.Debug("compatible reference items", logging.Stage, "reference", "count", len(compatibleChoices), "classType", getClassString(classType))
+
+	// If no compatible items found, show diagnostic info, scaling the rejected items is only worth it with debug logs
+	if g.debug && len(compatibleChoices) == 0 {
+		logger.Debug("no compatible reference item", logging.Stage, "reference", "candidates", len(highLevelItems), "name", item.Name,
+			"class", *item.Class, "subclass", *item.Subclass, "inventoryType", getInventoryTypeString(item.InventoryType), "classType", getClassString(classType))
+
+		// Show details of items that were considered but rejected
+		for i, highLevelItem := range highLevelItems {
+			if i >= 5 { // Limit to first 5 items to avoid spam
+				logger.Debug("more rejected reference items", logging.Stage, "reference", "count", len(highLevelItems)-5)
+				break
 			}
+			highLevelItem := items.ItemFromDbItem(highLevelItem)
+			highLevelItem.ScaleItem(*highLevelItem.ItemLevel, *item.Quality)
+			highClassType := highLevelItem.GetClassUserType()
+
+			logger.Debug("rejected reference item", logging.Stage, "reference", "reference", highLevelItem.Entry, "name", highLevelItem.Name,
+				"class", *highLevelItem.Class, "subclass", *highLevelItem.Subclass,
+				"inventoryType", getInventoryTypeString(highLevelItem.InventoryType), "classType", getClassString(highClassType),
+				"classMatch", highClassType == classType,
+				"inventoryTypeMatch", item.InventoryType != nil && highLevelItem.InventoryType != nil && *item.InventoryType == *highLevelItem.InventoryType)
 		}
 	}
 
@@ -1125,17 +1334,15 @@ func (g *MoltenCoreGenerator) GenerateItem(dbItem mysql.DbItem, validateOnly boo
 			similarSubclasses = getSimilarArmorSubclasses(subclassToUse)
 		}
 
-		if g.debug && len(similarSubclasses) > 0 {
-			log.Printf("No compatible items found for subclass %d, trying similar subclasses: %v", subclassToUse, similarSubclasses)
+		if len(similarSubclasses) > 0 {
+			logger.Debug("trying similar subclasses", logging.Stage, "reference", "subclass", subclassToUse, "similar", similarSubclasses)
 		}
 
 		// Try each similar subclass with same inventory type
 		for _, altSubclass := range similarSubclasses {
 			altHighLevelItems, err := g.db.GetRaidPhase1Items(*item.Class, altSubclass, 0, 0)
 			if err != nil {
-				if g.debug {
-					log.Printf("Error getting items for similar subclass %d: %v", altSubclass, err)
-				}
+				logger.Warn("failed to get the items of a similar subclass", logging.Stage, "reference", "subclass", altSubclass, "err", err)
 				continue
 			}
 
@@ -1156,9 +1363,7 @@ func (g *MoltenCoreGenerator) GenerateItem(dbItem mysql.DbItem, validateOnly boo
 			}
 
 			if len(compatibleChoices) > 0 {
-				if g.debug {
-					log.Printf("Found %d compatible items using similar subclass %d with same inventory type", len(compatibleChoices), altSubclass)
-				}
+				logger.Debug("compatible items in a similar subclass", logging.Stage, "reference", "count", len(compatibleChoices), "subclass", altSubclass)
 				break // Found some, no need to try more subclasses
 			}
 		}
@@ -1166,8 +1371,8 @@ func (g *MoltenCoreGenerator) GenerateItem(dbItem mysql.DbItem, validateOnly boo
 		// If still no matches, try equivalent inventory type groups
 		if len(compatibleChoices) == 0 {
 			equivalentInventoryTypes := getEquivalentInventoryTypes(item.InventoryType)
-			if g.debug && len(equivalentInventoryTypes) > 0 {
-				log.Printf("No compatible items found with same inventory type, trying equivalent inventory types: %v", equivalentInventoryTypes)
+			if len(equivalentInventoryTypes) > 0 {
+				logger.Debug("trying equivalent inventory types", logging.Stage, "reference", "inventoryTypes", equivalentInventoryTypes)
 			}
 
 			// Try original subclass with equivalent inventory types
@@ -1189,9 +1394,7 @@ func (g *MoltenCoreGenerator) GenerateItem(dbItem mysql.DbItem, validateOnly boo
 			}
 
 			if len(compatibleChoices) > 0 {
-				if g.debug {
-					log.Printf("Found %d compatible items using equivalent inventory types", len(compatibleChoices))
-				}
+				logger.Debug("compatible items with an equivalent inventory type", logging.Stage, "reference", "count", len(compatibleChoices))
 			} else {
 				// Try similar subclasses with equivalent inventory types
 				for _, altSubclass := range similarSubclasses {
@@ -1218,9 +1421,8 @@ func (g *MoltenCoreGenerator) GenerateItem(dbItem mysql.DbItem, validateOnly boo
 					}
 
 					if len(compatibleChoices) > 0 {
-						if g.debug {
-							log.Printf("Found %d compatible items using similar subclass %d with equivalent inventory types", len(compatibleChoices), altSubclass)
-						}
+						logger.Debug("compatible items in a similar subclass with an equivalent inventory type", logging.Stage, "reference",
+							"count", len(compatibleChoices), "subclass", altSubclass)
 						break // Found some, no need to try more subclasses
 					}
 				}
@@ -1232,7 +1434,7 @@ func (g *MoltenCoreGenerator) GenerateItem(dbItem mysql.DbItem, validateOnly boo
 	var selectedReferenceItem *items.Item
 	if len(compatibleChoices) > 0 {
 		// Use random compatible reference item
-		randHighLevelItem := compatibleChoices[rand.Intn(len(compatibleChoices))]
+		randHighLevelItem := compatibleChoices[rng.IntN(len(compatibleChoices))]
 		// Store reference item for comparison
 		selectedReferenceItem = &randHighLevelItem
 		// Apply stats from reference item (accept the scaling)
@@ -1242,15 +1444,11 @@ func (g *MoltenCoreGenerator) GenerateItem(dbItem mysql.DbItem, validateOnly boo
 		if !isTrinket(&item) {
 			// For non-trinkets: clear original spells and copy from reference item
 			copySpellsFromReference(&item, selectedReferenceItem)
-			if g.debug {
-				log.Printf("Copied spells from reference item %s to %s", selectedReferenceItem.Name, item.Name)
-			}
+			logger.Debug("copied the reference item's spells", logging.Stage, "spells", "reference", selectedReferenceItem.Entry, "name", item.Name)
 		} else {
 			// For trinkets: clear original spells (will be replaced by applyTrinketSpells later)
 			clearItemSpells(&item)
-			if g.debug {
-				log.Printf("Cleared original spells from trinket %s (will be replaced by applyTrinketSpells)", item.Name)
-			}
+			logger.Debug("cleared the trinket's spells for a trinket spell", logging.Stage, "spells", "name", item.Name)
 		}
 
 		item.ScaleItem(g.itemLevel, g.quality)
@@ -1258,14 +1456,10 @@ func (g *MoltenCoreGenerator) GenerateItem(dbItem mysql.DbItem, validateOnly boo
 		// Manual scaling required - clear original spells for consistency
 		if !isTrinket(&item) {
 			clearItemSpells(&item)
-			if g.debug {
-				log.Printf("Cleared original spells from %s (no reference item available)", item.Name)
-			}
+			logger.Debug("cleared the spells, no reference item", logging.Stage, "spells", "name", item.Name)
 		} else {
 			clearItemSpells(&item)
-			if g.debug {
-				log.Printf("Cleared original spells from trinket %s (manual scaling)", item.Name)
-			}
+			logger.Debug("cleared the trinket's spells, no reference item", logging.Stage, "spells", "name", item.Name)
 		}
 		result.Warnings = append(result.Warnings, "No compatible reference items found - FLAGGED FOR MANUAL REVIEW")
 		result.Errors = append(result.Errors, "MANUAL REVIEW REQUIRED: No reference items available for stat scaling")
@@ -1373,21 +1567,20 @@ func (g *MoltenCoreGenerator) applyTrinketSpells(item *items.Item, classType int
 		// Fallback to melee spells for unknown class types
 		spellOptions = meleeTrinketSpells
 		classDescription = "unknown (defaulting to melee)"
-		if g.debug {
-			log.Printf("Unknown class type %d for trinket %s, defaulting to melee spells", classType, item.Name)
-		}
+		slog.Debug("unknown class type for the trinket, using melee spells", logging.Entry, item.Entry, logging.Stage, "spells",
+			"name", item.Name, "classType", classType)
 	}
 
 	// MANDATORY: All trinkets must have a spell
 	if len(spellOptions) > 0 {
-		selectedSpell := spellOptions[rand.Intn(len(spellOptions))]
+		selectedSpell := spellOptions[rng.IntN(len(spellOptions))]
 		item.SpellId1 = &selectedSpell
-		if g.debug {
-			log.Printf("Applied %s trinket spell %d to %s", classDescription, selectedSpell, item.Name)
-		}
+		slog.Debug("applied a trinket spell", logging.Entry, item.Entry, logging.Spell, selectedSpell, logging.Stage, "spells",
+			"name", item.Name, "role", classDescription)
 	} else {
 		// This should never happen, but provide fallback
-		log.Printf("ERROR: No spell options available for class type %d, assigning default spell", classType)
+		slog.Error("no trinket spells for the class type, using the default spell", logging.Entry, item.Entry, logging.Stage, "spells",
+			"classType", classType)
 		defaultSpell := 60493 // Default to spell power trinket
 		item.SpellId1 = &defaultSpell
 	}
@@ -1414,7 +1607,8 @@ func (g *MoltenCoreGenerator) validateItem(item *items.Item, classType int) []st
 	}
 
 	// Validate stat limits based on plan requirements
-	for statType, statValue := range currentStats {
+	for _, statType := range sortedStatTypes(currentStats) {
+		statValue := currentStats[statType]
 		switch statType {
 		case 43: // Mana Regeneration
 			if statValue > 60 {
@@ -1495,9 +1689,7 @@ func (g *MoltenCoreGenerator) capStatValue(item *items.Item, statType, maxValue
 		if currentStatType != nil && currentStatValue != nil &&
 			*currentStatType == statType && *currentStatValue > maxValue {
 			*currentStatValue = maxValue
-			if g.debug {
-				log.Printf("Capped stat %d to %d for item %s", statType, maxValue, item.Name)
-			}
+			slog.Debug("capped a stat", logging.Entry, item.Entry, logging.Stage, "stats", "name", item.Name, "stat", statType, "max", maxValue)
 			break
 		}
 	}
@@ -1567,7 +1759,7 @@ func printThreeWayComparison(originalItem, referenceItem, scaledItem *items.Item
 	}
 
 	// Print stats comparison
-	for statType := range allStatTypes {
+	for _, statType := range sortedStatTypes(allStatTypes) {
 		originalValue := 0
 		scaledValue := 0
 		referenceValue := 0
@@ -1717,7 +1909,7 @@ func printItemComparisonWithOriginals(originalItem, scaledItem *items.Item, clas
 	}
 
 	// Print stats comparison
-	for statType := range allStatTypes {
+	for _, statType := range sortedStatTypes(allStatTypes) {
 		originalValue := 0
 		scaledValue := 0
 		statName := getStatName(statType)
@@ -1861,7 +2053,7 @@ func printItemComparison(originalItem, scaledItem *items.Item, classType int) {
 	}
 
 	// Print stats comparison
-	for statType := range allStatTypes {
+	for _, statType := range sortedStatTypes(allStatTypes) {
 		statName := getStatName(statType)
 		originalValue := originalStats[statType]
 		scaledValue := scaledStats[statType]
@@ -2019,7 +2211,8 @@ func (g *MoltenCoreGenerator) validateItemAdvanced(item *items.Item, classType i
 	}
 
 	// 2. STAT LIMIT VALIDATION (Critical - 30 points)
-	for statType, statValue := range currentStats {
+	for _, statType := range sortedStatTypes(currentStats) {
+		statValue := currentStats[statType]
 		switch statType {
 		case 43: // Mana Regeneration
 			if statValue > 60 {
@@ -2039,7 +2232,8 @@ func (g *MoltenCoreGenerator) validateItemAdvanced(item *items.Item, classType i
 
 	// 3. POWER STAT PRIORITY VALIDATION (Important - 20 points)
 	highestStatValue := g.getHighestStatValue(currentStats)
-	for statType, statValue := range currentStats {
+	for _, statType := range sortedStatTypes(currentStats) {
+		statValue := currentStats[statType]
 		switch statType {
 		case 38: // Attack Power
 			if classType == 1 || classType == 2 || classType == 3 {
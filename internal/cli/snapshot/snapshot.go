@@ -0,0 +1,48 @@
+package snapshot
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/cli"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlite"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+var Command = cli.Command{
+	Name:    "snapshot",
+	Summary: "export the world tables the generator reads into a SQLite file",
+	Run:     run,
+}
+
+// Exports the world tables the generator reads into a portable SQLite file. Pass the file to the
+// other commands with -snapshot to run them without a MySQL world server.
+func run(g *cli.Globals, args []string) error {
+	fs := g.FlagSet("snapshot", "", "path of the SQLite snapshot to write, replaced if it exists", "world.snapshot.db")
+	tables := fs.String("tables", strings.Join(sqlite.SnapshotTables, ","), "comma separated list of world tables to export")
+	if err := g.Parse(fs, args, false); err != nil {
+		return err
+	}
+
+	mysqlDb, err := mysql.Connect(nil)
+	if err != nil {
+		return err
+	}
+	defer mysqlDb.Close()
+
+	var tableList []string
+	for _, table := range strings.Split(*tables, ",") {
+		if table = strings.TrimSpace(table); table != "" {
+			tableList = append(tableList, table)
+		}
+	}
+
+	if err := sqlite.CreateSnapshot(mysqlDb, g.Out, tableList); err != nil {
+		return err
+	}
+
+	slog.Info("snapshot written", "path", g.Out)
+	return nil
+}
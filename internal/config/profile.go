@@ -0,0 +1,217 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ProfileVersion is the profile format this build reads
+const ProfileVersion = 2
+
+type LevelRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+type ItemLevelRanges struct {
+	Mythic    LevelRange `json:"mythic"`
+	Legendary LevelRange `json:"legendary"`
+	Ascendant LevelRange `json:"ascendant"`
+}
+
+// Profile is every tuning number the generator uses, loaded from a json file so it can be changed without a rebuild
+type Profile struct {
+	Version           int             `json:"version"`
+	Name              string          `json:"name"`
+	ItemLevels        ItemLevelRanges `json:"itemLevels"`
+	ItemRules         []ItemRule      `json:"itemRules"`
+	InvTypeModifiers  map[int]float64 `json:"invTypeModifiers"`
+	QualityModifiers  map[int]float64 `json:"qualityModifiers"`
+	MaterialModifiers map[int]float64 `json:"materialModifiers"`
+	GearTierModifiers map[int]float64 `json:"gearTierModifiers"`
+	StatModifiers     map[int]float64 `json:"statModifiers"`
+	ScalingFactor     map[int]float64 `json:"scalingFactor"`
+}
+
+// Keys every profile has to define
+var (
+	InventoryTypes = []int{0, 1, 2, 3, 5, 6, 7, 8, 9, 10, 11, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27}
+	Qualities      = []int{0, 1, 2, 3, 4, 5}
+	Materials      = []int{1, 2, 3, 4, 6}
+	GearTiers      = []int{1, 2, 3, 4, 5}
+	Difficulties   = []int{3, 4, 5}
+	Expansions     = []int{0, 1, 2}
+)
+
+// the built in profile is the values in modifier.go and itemLevels.go plus the item rules below,
+// copied before any profile file replaces them
+var defaultProfile = &Profile{
+	Version: ProfileVersion,
+	Name:    "default",
+	ItemLevels: ItemLevelRanges{
+		Mythic:    LevelRange{MythicItemLevelStart, MythicItemLevelEnd},
+		Legendary: LevelRange{LegendaryItemLevelStart, LegendaryItemLevelEnd},
+		Ascendant: LevelRange{AscendantItemLevelStart, AscendantItemLevelEnd},
+	},
+	// the boss rules go first and add up, then each dungeon bracket picks the bonus. Capped dungeons are
+	// the ones at the expansion max level (60, 70, 80), dungeons above it match no rule.
+	ItemRules: []ItemRule{
+		{Name: "boss drops are epic", Drops: bosses, Quality: 4, Continue: true},
+		{Name: "final boss bonus", Drops: []string{FinalBossDrop}, ItemLevel: 5, Continue: true},
+		{Name: "legendary final boss drops", Drops: []string{FinalBossDrop}, Difficulties: []int{4, 5}, Quality: 5, Continue: true},
+		{Name: "mythic boss required level", Drops: bosses, Difficulties: []int{3}, RequiredLevel: 2, Continue: true},
+		{Name: "legendary boss required level", Drops: bosses, Difficulties: []int{4, 5}, RequiredLevel: 5, Continue: true},
+		{Name: "world drops", Drops: []string{WorldDrop}},
+		{Name: "classic dungeons", Expansions: []int{0}, DungeonLevel: &LevelRange{0, 59}, Drops: []string{TrashDrop}, ItemLevel: 5},
+		{Name: "classic dungeon bosses", Expansions: []int{0}, DungeonLevel: &LevelRange{0, 59}, Drops: bosses, ItemLevel: 9, RequiredLevel: -1},
+		{Name: "classic max level dungeons", Expansions: []int{0}, DungeonLevel: &LevelRange{60, 60}, Drops: []string{TrashDrop}, ItemLevel: 10},
+		{Name: "classic max level dungeon bosses", Expansions: []int{0}, DungeonLevel: &LevelRange{60, 60}, Drops: bosses, ItemLevel: 23},
+		{Name: "tbc dungeons", Expansions: []int{1}, DungeonLevel: &LevelRange{0, 69}, Drops: []string{TrashDrop}, ItemLevel: 7},
+		{Name: "tbc dungeon bosses", Expansions: []int{1}, DungeonLevel: &LevelRange{0, 69}, Drops: bosses, ItemLevel: 10, RequiredLevel: -1},
+		{Name: "tbc max level dungeons", Expansions: []int{1}, DungeonLevel: &LevelRange{70, 70}, Drops: []string{TrashDrop}, ItemLevel: 10},
+		{Name: "tbc max level dungeon bosses", Expansions: []int{1}, DungeonLevel: &LevelRange{70, 70}, Drops: bosses, ItemLevel: 23},
+		{Name: "wotlk dungeons", Expansions: []int{2}, DungeonLevel: &LevelRange{0, 79}, Drops: []string{TrashDrop}, ItemLevel: 7},
+		{Name: "wotlk dungeon bosses", Expansions: []int{2}, DungeonLevel: &LevelRange{0, 79}, Drops: bosses, ItemLevel: 12, RequiredLevel: -1},
+		{Name: "wotlk max level dungeons", Expansions: []int{2}, DungeonLevel: &LevelRange{80, 80}, Drops: []string{TrashDrop}, ItemLevel: 10, RequiredLevel: 2},
+		{Name: "wotlk max level dungeon bosses", Expansions: []int{2}, DungeonLevel: &LevelRange{80, 80}, Drops: bosses, ItemLevel: 25},
+	},
+	InvTypeModifiers:  copyModifiers(InvTypeModifiers),
+	QualityModifiers:  copyModifiers(QualityModifiers),
+	MaterialModifiers: copyModifiers(MaterialModifiers),
+	GearTierModifiers: copyModifiers(GearTierModifiers),
+	StatModifiers:     copyModifiers(StatModifiers),
+	ScalingFactor:     copyModifiers(ScalingFactor),
+}
+
+var bosses = []string{BossDrop, FinalBossDrop}
+
+var current = defaultProfile
+
+// DefaultProfile is the built in profile the tools use without -profile
+func DefaultProfile() *Profile {
+	return defaultProfile
+}
+
+// LoadProfile reads and validates the profile at path, an empty path is the built in default profile
+func LoadProfile(path string) (*Profile, error) {
+	if path == "" {
+		return defaultProfile, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profile %s: %w", path, err)
+	}
+
+	profile, err := ParseProfile(data)
+	if err != nil {
+		return nil, fmt.Errorf("profile %s: %w", path, err)
+	}
+	return profile, nil
+}
+
+func ParseProfile(data []byte) (*Profile, error) {
+	profile := &Profile{}
+	if err := json.Unmarshal(data, profile); err != nil {
+		return nil, fmt.Errorf("failed to parse profile: %w", err)
+	}
+
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// Validate checks the version, the item rules and that every stat, inventory type, quality, material
+// and tier has a value so a typo can't silently fall back to a zero modifier
+func (p *Profile) Validate() error {
+	if p.Version != ProfileVersion {
+		return fmt.Errorf("unsupported profile version %d, expected %d", p.Version, ProfileVersion)
+	}
+
+	problems := []string{}
+	missing := func(name string, keys []int, present func(int) bool) {
+		for _, key := range keys {
+			if !present(key) {
+				problems = append(problems, fmt.Sprintf("%s is missing key %d", name, key))
+			}
+		}
+	}
+	inFloats := func(m map[int]float64) func(int) bool {
+		return func(key int) bool { _, ok := m[key]; return ok }
+	}
+
+	missing("invTypeModifiers", InventoryTypes, inFloats(p.InvTypeModifiers))
+	missing("qualityModifiers", Qualities, inFloats(p.QualityModifiers))
+	missing("materialModifiers", Materials, inFloats(p.MaterialModifiers))
+	missing("gearTierModifiers", GearTiers, inFloats(p.GearTierModifiers))
+	missing("statModifiers", StatIds(), inFloats(p.StatModifiers))
+	missing("scalingFactor", StatIds(), inFloats(p.ScalingFactor))
+	problems = append(problems, p.validateRules()...)
+
+	for name, r := range map[string]LevelRange{"mythic": p.ItemLevels.Mythic, "legendary": p.ItemLevels.Legendary, "ascendant": p.ItemLevels.Ascendant} {
+		if r.Start <= 0 || r.End < r.Start {
+			problems = append(problems, fmt.Sprintf("itemLevels.%s range %d-%d is invalid", name, r.Start, r.End))
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return fmt.Errorf("invalid profile %q:\n  %s", p.Name, strings.Join(problems, "\n  "))
+	}
+	return nil
+}
+
+// String is the resolved profile as indented json for audit output
+func (p *Profile) String() string {
+	out, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("failed to print profile: %v", err)
+	}
+	return string(out)
+}
+
+// Use makes the profile the one the package modifiers and level ranges come from
+func Use(p *Profile) {
+	current = p
+
+	InvTypeModifiers = p.InvTypeModifiers
+	QualityModifiers = p.QualityModifiers
+	MaterialModifiers = p.MaterialModifiers
+	GearTierModifiers = p.GearTierModifiers
+	StatModifiers = p.StatModifiers
+	ScalingFactor = p.ScalingFactor
+
+	MythicItemLevelStart = p.ItemLevels.Mythic.Start
+	MythicItemLevelEnd = p.ItemLevels.Mythic.End
+	LegendaryItemLevelStart = p.ItemLevels.Legendary.Start
+	LegendaryItemLevelEnd = p.ItemLevels.Legendary.End
+	AscendantItemLevelStart = p.ItemLevels.Ascendant.Start
+	AscendantItemLevelEnd = p.ItemLevels.Ascendant.End
+}
+
+// CurrentProfile is the profile in use, the default one unless a tool loaded another
+func CurrentProfile() *Profile {
+	return current
+}
+
+func copyModifiers(m map[int]float64) map[int]float64 {
+	out := make(map[int]float64, len(m))
+	for key, value := range m {
+		out[key] = value
+	}
+	return out
+}
+
+// StatIds are the item stat types the generator knows about
+func StatIds() []int {
+	ids := make([]int, 0, len(StatModifierNames))
+	for id := range StatModifierNames {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDefaultProfileFile(t *testing.T) {
+	profile, err := LoadProfile("../../profiles/default.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(profile, DefaultProfile()) {
+		t.Error("profiles/default.json is out of date with the built in profile, regenerate it with -print-profile")
+	}
+}
+
+func TestValidateProfile(t *testing.T) {
+	tests := []struct {
+		name     string
+		edit     func(p map[string]interface{})
+		expected string
+	}{
+		{"default", func(p map[string]interface{}) {}, ""},
+		{"version", func(p map[string]interface{}) { p["version"] = 1 }, "unsupported profile version 1"},
+		{"stat", func(p map[string]interface{}) { delete(p["statModifiers"].(map[string]interface{}), "31") }, "statModifiers is missing key 31"},
+		{"inventory type", func(p map[string]interface{}) { delete(p["invTypeModifiers"].(map[string]interface{}), "17") }, "invTypeModifiers is missing key 17"},
+		{"quality", func(p map[string]interface{}) { delete(p["qualityModifiers"].(map[string]interface{}), "5") }, "qualityModifiers is missing key 5"},
+		{"no rules", func(p map[string]interface{}) { p["itemRules"] = []interface{}{} }, "itemRules is empty"},
+		{"rule drop", func(p map[string]interface{}) {
+			p["itemRules"].([]interface{})[0].(map[string]interface{})["drops"] = []string{"raid"}
+		}, `itemRules[0] "boss drops are epic" drop "raid" is not one of world, trash, boss, final-boss`},
+		{"rule difficulty", func(p map[string]interface{}) {
+			p["itemRules"].([]interface{})[2].(map[string]interface{})["difficulties"] = []int{6}
+		}, "difficulty 6 is not 3, 4 or 5"},
+		{"rule level range", func(p map[string]interface{}) {
+			p["itemRules"].([]interface{})[6].(map[string]interface{})["dungeonLevel"] = map[string]int{"start": 59, "end": 1}
+		}, "dungeonLevel 59-1 is invalid"},
+		{"level range", func(p map[string]interface{}) {
+			p["itemLevels"].(map[string]interface{})["mythic"] = map[string]int{"start": 340, "end": 300}
+		}, "itemLevels.mythic range 340-300 is invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(DefaultProfile().String()), &raw); err != nil {
+				t.Fatal(err)
+			}
+			tt.edit(raw)
+
+			data, err := json.Marshal(raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = ParseProfile(data)
+			if tt.expected == "" {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("error = %v, want %q", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMatchRules(t *testing.T) {
+	tests := []struct {
+		name          string
+		drop          Drop
+		ok            bool
+		itemLevel     int
+		requiredLevel int
+		quality       int
+	}{
+		{"world", Drop{Kind: WorldDrop, Difficulty: 3}, true, 0, 0, 0},
+		{"classic", Drop{TrashDrop, 0, 45, 3}, true, 5, 0, 0},
+		{"classic boss", Drop{BossDrop, 0, 45, 3}, true, 9, 1, 4},
+		{"classic capped", Drop{TrashDrop, 0, 60, 4}, true, 10, 0, 0},
+		{"classic capped boss", Drop{BossDrop, 0, 60, 4}, true, 23, 5, 4},
+		{"tbc", Drop{TrashDrop, 1, 68, 3}, true, 7, 0, 0},
+		{"tbc boss", Drop{BossDrop, 1, 68, 5}, true, 10, 4, 4},
+		{"wotlk capped", Drop{TrashDrop, 2, 80, 3}, true, 10, 2, 0},
+		{"wotlk final boss mythic", Drop{FinalBossDrop, 2, 80, 3}, true, 30, 2, 4},
+		{"wotlk final boss legendary", Drop{FinalBossDrop, 2, 80, 4}, true, 30, 5, 5},
+		{"above cap", Drop{TrashDrop, 0, 62, 3}, false, 0, 0, 0},
+		{"boss above cap", Drop{BossDrop, 0, 62, 3}, false, 0, 2, 4},
+	}
+
+	for _, tt := range tests {
+		result, ok := DefaultProfile().MatchRules(tt.drop)
+		if ok != tt.ok || result.ItemLevel != tt.itemLevel || result.RequiredLevel != tt.requiredLevel || result.Quality != tt.quality {
+			t.Errorf("%s: got %+v %v", tt.name, result, ok)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Drop kinds a rule can match, a world drop is an item that is not in dungeon_items
+const (
+	WorldDrop     = "world"
+	TrashDrop     = "trash"
+	BossDrop      = "boss"
+	FinalBossDrop = "final-boss"
+)
+
+var DropKinds = []string{WorldDrop, TrashDrop, BossDrop, FinalBossDrop}
+
+// ItemRule sets the item level, required level and quality of the items it matches. An empty list or
+// range matches everything. Rules are tried in order and the first one that matches decides the item,
+// unless it has continue set, then its numbers are added and the rules below it are tried too.
+type ItemRule struct {
+	Name         string      `json:"name"`
+	Expansions   []int       `json:"expansions,omitempty"`
+	DungeonLevel *LevelRange `json:"dungeonLevel,omitempty"`
+	Drops        []string    `json:"drops,omitempty"`
+	Difficulties []int       `json:"difficulties,omitempty"`
+
+	ItemLevel     int  `json:"itemLevel"`          // added to -ilvl
+	RequiredLevel int  `json:"requiredLevel"`      // added to -baselevel
+	Quality       int  `json:"quality,omitempty"`  // 0 keeps the item's quality
+	Continue      bool `json:"continue,omitempty"` // keep trying the rules below
+}
+
+// Drop is what the rules know about a source item
+type Drop struct {
+	Kind         string
+	Expansion    int
+	DungeonLevel int
+	Difficulty   int
+}
+
+func (d Drop) String() string {
+	if d.Kind == WorldDrop {
+		return fmt.Sprintf("%s drop on difficulty %d", d.Kind, d.Difficulty)
+	}
+	return fmt.Sprintf("%s drop from a level %d expansion %d dungeon on difficulty %d", d.Kind, d.DungeonLevel, d.Expansion, d.Difficulty)
+}
+
+// RuleResult is what the matching rules add up to
+type RuleResult struct {
+	ItemLevel     int
+	RequiredLevel int
+	Quality       int
+	Rules         []string // names of the matching rules in order
+}
+
+func (r ItemRule) Matches(drop Drop) bool {
+	if r.DungeonLevel != nil && (drop.DungeonLevel < r.DungeonLevel.Start || drop.DungeonLevel > r.DungeonLevel.End) {
+		return false
+	}
+	return matchesInt(r.Expansions, drop.Expansion) && matchesInt(r.Difficulties, drop.Difficulty) && matchesString(r.Drops, drop.Kind)
+}
+
+// MatchRules runs the drop through the rules. It is false when the drop got to the end of the rules
+// without a rule that stops, those items are not generated.
+func (p *Profile) MatchRules(drop Drop) (RuleResult, bool) {
+	result := RuleResult{}
+	for _, rule := range p.ItemRules {
+		if !rule.Matches(drop) {
+			continue
+		}
+		result.ItemLevel += rule.ItemLevel
+		result.RequiredLevel += rule.RequiredLevel
+		if rule.Quality != 0 {
+			result.Quality = rule.Quality
+		}
+		result.Rules = append(result.Rules, rule.Name)
+		if !rule.Continue {
+			return result, true
+		}
+	}
+	return result, false
+}
+
+func (p *Profile) validateRules() []string {
+	problems := []string{}
+	if len(p.ItemRules) == 0 {
+		problems = append(problems, "itemRules is empty")
+	}
+	for i, rule := range p.ItemRules {
+		name := fmt.Sprintf("itemRules[%d] %q", i, rule.Name)
+		if rule.Name == "" {
+			problems = append(problems, fmt.Sprintf("itemRules[%d] has no name", i))
+		}
+		for _, drop := range rule.Drops {
+			if !matchesString(DropKinds, drop) {
+				problems = append(problems, fmt.Sprintf("%s drop %q is not one of %s", name, drop, strings.Join(DropKinds, ", ")))
+			}
+		}
+		for _, expansion := range rule.Expansions {
+			if !matchesInt(Expansions, expansion) {
+				problems = append(problems, fmt.Sprintf("%s expansion %d is not 0, 1 or 2", name, expansion))
+			}
+		}
+		for _, difficulty := range rule.Difficulties {
+			if !matchesInt(Difficulties, difficulty) {
+				problems = append(problems, fmt.Sprintf("%s difficulty %d is not 3, 4 or 5", name, difficulty))
+			}
+		}
+		if r := rule.DungeonLevel; r != nil && r.End < r.Start {
+			problems = append(problems, fmt.Sprintf("%s dungeonLevel %d-%d is invalid", name, r.Start, r.End))
+		}
+		if rule.Quality < 0 || rule.Quality > 5 {
+			problems = append(problems, fmt.Sprintf("%s quality %d is not between 0 and 5", name, rule.Quality))
+		}
+	}
+	return problems
+}
+
+func matchesInt(list []int, value int) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesString(list []string, value string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package apply
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/store"
+)
+
+// Tx is what a run needs from the transaction, store.Tx and *sqlx.Tx both fit
+type Tx interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Get(dest interface{}, query string, args ...interface{}) error
+	Commit() error
+	Rollback() error
+}
+
+// Counts are the rows a run wrote to one table
+type Counts struct {
+	Inserted int
+	Updated  int
+	Skipped  int
+}
+
+// Run applies every write of a generation run in one transaction. Nothing is kept unless Finish
+// commits it, a dry run does all the writes so the counts are exact and then rolls them back.
+type Run struct {
+	tx     Tx
+	DryRun bool
+	tables []string
+	counts map[string]*Counts
+	done   bool
+}
+
+// Begin starts a run on the world database, the returned store does its reads and writes in the run's transaction
+func Begin(s store.Store, dryRun bool) (*Run, store.Tx, error) {
+	tx, err := store.Begin(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	return NewRun(tx, dryRun), tx, nil
+}
+
+func NewRun(tx Tx, dryRun bool) *Run {
+	return &Run{
+		tx:     tx,
+		DryRun: dryRun,
+		counts: map[string]*Counts{},
+	}
+}
+
+// Track runs write and counts the row table.key = id as inserted, updated or skipped
+// depending on whether it was there before and after
+func (r *Run) Track(table, key string, id int, write func() error) error {
+	existed, err := r.exists(table, key, id)
+	if err != nil {
+		return err
+	}
+
+	if err := write(); err != nil {
+		return fmt.Errorf("failed to write %s %s %d: %w", table, key, id, err)
+	}
+
+	exists, err := r.exists(table, key, id)
+	if err != nil {
+		return err
+	}
+
+	counts := r.table(table)
+	switch {
+	case !exists:
+		// the row it copies from is missing so nothing was written
+		counts.Skipped++
+	case existed:
+		counts.Updated++
+	default:
+		counts.Inserted++
+	}
+	return nil
+}
+
+// Exec runs the statements for the row table.key = id, see Track
+func (r *Run) Exec(table, key string, id int, statements ...string) error {
+	return r.Track(table, key, id, func() error {
+		for _, statement := range statements {
+			if _, err := r.tx.Exec(statement); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Run) exists(table, key string, id int) (bool, error) {
+	var count int
+	if err := r.tx.Get(&count, fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s = ?", table, key), id); err != nil {
+		return false, fmt.Errorf("failed to check %s %s %d: %w", table, key, id, err)
+	}
+	return count > 0, nil
+}
+
+func (r *Run) table(table string) *Counts {
+	counts, ok := r.counts[table]
+	if !ok {
+		counts = &Counts{}
+		r.counts[table] = counts
+		r.tables = append(r.tables, table)
+	}
+	return counts
+}
+
+// Counts for table, zero if the run never wrote to it
+func (r *Run) Counts(table string) Counts {
+	if counts, ok := r.counts[table]; ok {
+		return *counts
+	}
+	return Counts{}
+}
+
+// Finish commits the run, or rolls it back for a dry run
+func (r *Run) Finish() error {
+	if r.done {
+		return nil
+	}
+	r.done = true
+
+	if r.DryRun {
+		return r.tx.Rollback()
+	}
+	return r.tx.Commit()
+}
+
+// Rollback throws away every write of the run, safe to call after Finish
+func (r *Run) Rollback() error {
+	if r.done {
+		return nil
+	}
+	r.done = true
+
+	return r.tx.Rollback()
+}
+
+// PrintSummary writes the counts per table in the order the tables were first written
+func (r *Run) PrintSummary(w io.Writer) {
+	title := "Applied"
+	if r.DryRun {
+		title = "Dry run, rolled back"
+	}
+	fmt.Fprintf(w, "%s:\n", title)
+
+	if len(r.tables) == 0 {
+		fmt.Fprintln(w, "  nothing written")
+		return
+	}
+
+	width := 0
+	for _, table := range r.tables {
+		width = max(width, len(table))
+	}
+
+	total := Counts{}
+	for _, table := range r.tables {
+		counts := r.counts[table]
+		fmt.Fprintf(w, "  %-*s  %6d inserted %6d updated %6d skipped\n", width, table, counts.Inserted, counts.Updated, counts.Skipped)
+		total.Inserted += counts.Inserted
+		total.Updated += counts.Updated
+		total.Skipped += counts.Skipped
+	}
+	fmt.Fprintf(w, "  %-*s  %6d inserted %6d updated %6d skipped\n", width, "total", total.Inserted, total.Updated, total.Skipped)
+}
+
+func (r *Run) String() string {
+	out := new(strings.Builder)
+	r.PrintSummary(out)
+	return out.String()
+}
@@ -0,0 +1,101 @@
+package apply
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestDb(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	db, err := sqlx.Open("sqlite3", t.TempDir()+"/world.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	db.MustExec("CREATE TABLE item_template (entry INTEGER PRIMARY KEY, name TEXT)")
+	db.MustExec("INSERT INTO item_template VALUES (1, 'Sword'), (20000001, 'Mythic Sword')")
+	return db
+}
+
+func countRows(t *testing.T, db *sqlx.DB) int {
+	var count int
+	if err := db.Get(&count, "SELECT COUNT(*) FROM item_template"); err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func runWrites(t *testing.T, db *sqlx.DB, dryRun bool) *Run {
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	run := NewRun(tx, dryRun)
+
+	writes := []struct {
+		id        int
+		statement string
+	}{
+		{20000001, "UPDATE item_template SET name = 'Fabled Sword' WHERE entry = 20000001"},
+		{20000002, "INSERT INTO item_template SELECT entry + 20000000, name FROM item_template WHERE entry = 2"},
+		{20000003, "INSERT INTO item_template VALUES (20000003, 'Mythic Axe')"},
+	}
+	for _, write := range writes {
+		if err := run.Exec("item_template", "entry", write.id, write.statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := run.Finish(); err != nil {
+		t.Fatal(err)
+	}
+	return run
+}
+
+func TestRunCounts(t *testing.T) {
+	for _, dryRun := range []bool{false, true} {
+		db := newTestDb(t)
+		run := runWrites(t, db, dryRun)
+
+		expected := Counts{Inserted: 1, Updated: 1, Skipped: 1}
+		if got := run.Counts("item_template"); got != expected {
+			t.Errorf("dry run %v: counts = %+v, want %+v", dryRun, got, expected)
+		}
+
+		rows := 3
+		if dryRun {
+			rows = 2
+		}
+		if got := countRows(t, db); got != rows {
+			t.Errorf("dry run %v: %d rows after the run, want %d", dryRun, got, rows)
+		}
+	}
+}
+
+func TestRunRollsBackOnError(t *testing.T) {
+	db := newTestDb(t)
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	run := NewRun(tx, false)
+
+	if err := run.Exec("item_template", "entry", 20000003, "INSERT INTO item_template VALUES (20000003, 'Mythic Axe')"); err != nil {
+		t.Fatal(err)
+	}
+	err = run.Track("item_template", "entry", 20000004, func() error { return errors.New("boom") })
+	if err == nil {
+		t.Fatal("expected the failed write to return an error")
+	}
+	if err := run.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := countRows(t, db); got != 2 {
+		t.Errorf("%d rows after rollback, want 2", got)
+	}
+}
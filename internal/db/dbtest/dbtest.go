@@ -0,0 +1,40 @@
+// Package dbtest builds world tables for tests from the db tags of the mysql row structs
+package dbtest
+
+import (
+	"reflect"
+	"strings"
+)
+
+// TableSql is a create statement with a column for every field of row named by its db tag, so the test
+// tables always match the selected fields. The first field is the primary key like entry and ID in the
+// world tables and the others default to 0. extra are columns row has no field for.
+func TableSql(table string, row interface{}, extra ...string) string {
+	t := reflect.TypeOf(row)
+	names := []string{}
+	cols := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Tag.Get("db")
+		if name == "" {
+			name = strings.ToLower(t.Field(i).Name)
+		}
+		names = append(names, name)
+		if i == 0 {
+			cols = append(cols, "`"+name+"` INTEGER PRIMARY KEY")
+			continue
+		}
+		cols = append(cols, "`"+name+"` DEFAULT 0")
+	}
+
+	for _, name := range extra {
+		found := false
+		for _, existing := range names {
+			found = found || strings.EqualFold(existing, name)
+		}
+		if !found {
+			names = append(names, name)
+			cols = append(cols, "`"+name+"` DEFAULT 0")
+		}
+	}
+	return "CREATE TABLE " + table + " (" + strings.Join(cols, ", ") + ")"
+}
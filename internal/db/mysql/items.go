@@ -3,11 +3,14 @@ package mysql
 import (
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"reflect"
 	"strings"
 	"time"
 
 	"github.com/araxiaonline/endgame-item-generator/internal/config"
+	"github.com/araxiaonline/endgame-item-generator/internal/ids"
+	"github.com/araxiaonline/endgame-item-generator/internal/logging"
 )
 
 type DbItem struct {
@@ -18,6 +21,7 @@ type DbItem struct {
 	ItemLevel      *int `db:"ItemLevel"`
 	Class          *int
 	Subclass       *int
+	SoundOverride  *int     `db:"SoundOverrideSubclass"`
 	Armor          *int     `db:"armor"`
 	Material       *int     `db:"material"`
 	InventoryType  *int     `db:"inventoryType"`
@@ -125,6 +129,16 @@ func (db *MySqlDb) GetItem(entry int) (DbItem, error) {
 	return item, nil
 }
 
+// GetAllItems is every item_template row below the generated items, for preloading the whole catalog
+func (db *MySqlDb) GetAllItems() ([]DbItem, error) {
+	items := []DbItem{}
+	sql := fmt.Sprintf("SELECT %s FROM item_template WHERE entry < %d ORDER BY entry", GetItemFields(""), ids.GeneratedBase(ids.Items))
+	if err := db.Select(&items, sql); err != nil {
+		return nil, fmt.Errorf("failed to get the items: %v", err)
+	}
+	return items, nil
+}
+
 // Look up a mythic item by name
 func (db *MySqlDb) GetByNameAndDifficulty(name string, difficulty int) (DbItem, error) {
 	item := DbItem{}
@@ -153,24 +167,25 @@ func (db *MySqlDb) GetByNameAndDifficulty(name string, difficulty int) (DbItem,
 	name = "%" + name
 	err := db.Get(&item, sql, name, min, max)
 	if err != nil {
-		log.Printf("failed to get item: %v sql: %v", err, fmt.Sprintf("SELECT * FROM item_template WHERE name like %v and ItemLevel >= %v and ItemLevel < %v LIMIT 1", name, min, max))
+		slog.Debug("failed to get item by name", "name", name, "minLevel", min, "maxLevel", max, "err", err)
 		return DbItem{}, err
 	}
 
 	return item, nil
 }
 
-// returns all items from item_template where the quality is between rare and legendary items
-func (db *MySqlDb) GetRarePlusItems(limit, offset int) ([]DbItem, error) {
+// returns all items from item_template where the quality is between rare and legendary items, where is
+// an extra condition on item_template with ? placeholders for args
+func (db *MySqlDb) GetRarePlusItems(where string, args []interface{}, limit, offset int) ([]DbItem, error) {
 	items := []DbItem{}
 	sql := "SELECT " + GetItemFields("") + " FROM item_template WHERE Quality >= 3 and Quality <= 5 and (class = 2 or class = 4) "
-	sql += "and subclass != 20 AND entry < 20000000 ORDER BY entry ASC"
-
-	if limit != 0 && offset != 0 {
-		sql += fmt.Sprintf("LIMIT %v OFFSET %v", limit, offset)
+	sql += fmt.Sprintf("and subclass != 20 AND entry < %d", ids.GeneratedBase(ids.Items))
+	if where != "" {
+		sql += " AND (" + where + ")"
 	}
+	sql += " ORDER BY entry ASC" + limitClause(limit, offset)
 
-	err := db.Select(&items, sql)
+	err := db.Select(&items, sql, args...)
 	if err != nil {
 		return []DbItem{}, err
 	}
@@ -178,6 +193,22 @@ func (db *MySqlDb) GetRarePlusItems(limit, offset int) ([]DbItem, error) {
 	return items, nil
 }
 
+// limitClause is the LIMIT and OFFSET of a query, 0 is no limit or offset
+func limitClause(limit, offset int) string {
+	if limit == 0 && offset == 0 {
+		return ""
+	}
+	// an offset needs a limit, the largest one reads to the end
+	clause := " LIMIT 18446744073709551615"
+	if limit != 0 {
+		clause = fmt.Sprintf(" LIMIT %d", limit)
+	}
+	if offset != 0 {
+		clause += fmt.Sprintf(" OFFSET %d", offset)
+	}
+	return clause
+}
+
 func (db *MySqlDb) GetBossMapItems(mapId int, bossEntries []int, gameObjectEntries []int, limit, offset int) ([]DbItem, error) {
 	items := []DbItem{}
 
@@ -288,6 +319,23 @@ WHERE
 	return items, nil
 }
 
+// Clone is a copy of the item that shares none of its pointer fields, so a cached item can be handed out
+// and changed without changing the cache
+func (item DbItem) Clone() DbItem {
+	clone := item
+	value := reflect.ValueOf(&clone).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.Kind() != reflect.Ptr || field.IsNil() {
+			continue
+		}
+		copied := reflect.New(field.Type().Elem())
+		copied.Elem().Set(field.Elem())
+		field.Set(copied)
+	}
+	return clone
+}
+
 func GetItemFields(prefix string) string {
 	pre := ""
 	if prefix != "" {
@@ -296,7 +344,7 @@ func GetItemFields(prefix string) string {
 
 	return `	
 	` + pre + `entry, ` + pre + `name, ` + pre + `displayid,
-	quality, ItemLevel, class, subclass, inventoryType,
+	quality, ItemLevel, class, subclass, SoundOverrideSubclass, inventoryType,
 	allowableClass, allowableRace,
 	armor,material,
 	holy_res, fire_res, nature_res, frost_res, shadow_res, arcane_res,
@@ -366,7 +414,7 @@ func (db *MySqlDb) CopyItem(sourceTable string, destTable string, itemEntry int,
 	sql = fmt.Sprintf("DROP TEMPORARY TABLE IF EXISTS %s", tempTableName)
 	_, err = db.Exec(sql)
 	if err != nil {
-		log.Printf("Warning: failed to drop temporary table %s: %v", tempTableName, err)
+		slog.Warn("failed to drop temporary table", "table", tempTableName, logging.Entry, itemEntry, "err", err)
 	}
 
 	return nil
@@ -380,7 +428,7 @@ func (db *MySqlDb) WriteItem(table string, item DbItem) error {
 	// Construct the SQL insert statement using the item fields
 	sql := "INSERT INTO " + table + " (" + fields + ") VALUES (" +
 		"?, ?, ?, " + // entry, name, displayid
-		"?, ?, ?, ?, ?, " + // quality, ItemLevel, class, subclass, inventoryType
+		"?, ?, ?, ?, ?, ?, " + // quality, ItemLevel, class, subclass, SoundOverrideSubclass, inventoryType
 		"?, ?, " + // allowableClass, allowableRace
 		"?, ?, " + // armor, material
 		"?, ?, ?, ?, ?, ?, " + // holy_res, fire_res, nature_res, frost_res, shadow_res, arcane_res
@@ -418,9 +466,21 @@ func (db *MySqlDb) WriteItem(table string, item DbItem) error {
 		"spellid_1 = VALUES(spellid_1), spellid_2 = VALUES(spellid_2), spellid_3 = VALUES(spellid_3)"
 
 	// Execute the query with all the item fields as parameters
-	_, err := db.Exec(sql,
+	_, err := db.Exec(sql, ItemWriteArgs(item)...)
+
+	if err != nil {
+		slog.Error("failed to insert item", "table", table, logging.Entry, item.Entry, "sql", sql)
+		return fmt.Errorf("failed to insert item into %s: %w", table, err)
+	}
+
+	return nil
+}
+
+// ItemWriteArgs returns the item values in the same order as GetItemFields for use as query parameters
+func ItemWriteArgs(item DbItem) []interface{} {
+	return []interface{}{
 		item.Entry, item.Name, item.DisplayId,
-		item.Quality, item.ItemLevel, item.Class, item.Subclass, item.InventoryType,
+		item.Quality, item.ItemLevel, item.Class, item.Subclass, item.SoundOverride, item.InventoryType,
 		item.AllowableClass, item.AllowableRace,
 		item.Armor, item.Material,
 		item.HolyRes, item.FireRes, item.NatureRes, item.FrostRes, item.ShadowRes, item.ArcaneRes,
@@ -446,32 +506,31 @@ func (db *MySqlDb) WriteItem(table string, item DbItem) error {
 		item.SocketColor2, item.SocketContent2,
 		item.SocketColor3, item.SocketContent3,
 		item.SocketBonus, item.GemProperties,
-	)
-
-	if err != nil {
-		log.Printf("Failed to run sql query: %v", sql)
-		return fmt.Errorf("failed to insert item into %s: %w", table, err)
 	}
-
-	return nil
 }
 
 // This will convert a DbItemCsv to a DbItem and return
 // It will first look up the original item in the database though first and populate
 // the DbItem with the original item's values then override with the values from the csv
 func (db *MySqlDb) ConvertCsvToDbItem(csv DbItemCsv) (DbItem, error) {
+	return ConvertCsvToDbItem(db, csv)
+}
+
+// ConvertCsvToDbItem is the store independent version of MySqlDb.ConvertCsvToDbItem
+func ConvertCsvToDbItem(db interface {
+	GetItem(entry int) (DbItem, error)
+}, csv DbItemCsv) (DbItem, error) {
 
 	// Try to find the original item in the database
-	lookupEntry := csv.Entry - 2000000
+	lookupEntry := ids.VendorItems.Source(csv.Entry)
 	item, err := db.GetItem(lookupEntry)
 	if err != nil {
-		// Log the error with more details
-		log.Printf("Error finding original item with entry %d: %v", lookupEntry, err)
+		slog.Warn("failed to find the original item", logging.Entry, lookupEntry, "err", err)
 
 		item, err = db.GetItem(csv.Entry)
 
 		if err != nil {
-			log.Printf("Error finding item with direct entry %d: %v", csv.Entry, err)
+			slog.Warn("failed to find the item, using the csv row", logging.Entry, csv.Entry, "err", err)
 
 			// Create a new item with the CSV data
 			item = DbItem{
@@ -97,6 +97,11 @@ var raidLevels = map[int]int{
 	724: 80, // The Ruby Sanctum
 }
 
+// DungeonLevel returns the average player level of a dungeon map or 0 when it is not known
+func DungeonLevel(mapId int) int {
+	return dungeonLevels[mapId]
+}
+
 func (db *MySqlDb) GetDungeons(expansionId int) ([]Dungeon, error) {
 	dungeons := []Dungeon{}
 
@@ -118,9 +123,7 @@ func (db *MySqlDb) GetDungeons(expansionId int) ([]Dungeon, error) {
 	}
 
 	for i := range dungeons {
-		if level, ok := dungeonLevels[dungeons[i].Id]; ok {
-			dungeons[i].Level = level
-		}
+		dungeons[i].Level = DungeonLevel(dungeons[i].Id)
 	}
 
 	return dungeons, nil
@@ -1,9 +1,11 @@
 package mysql
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"os"
+	"sync/atomic"
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
@@ -11,6 +13,9 @@ import (
 
 type MySqlDb struct {
 	*sqlx.DB
+
+	// set on the copy returned by Begin, every query then runs inside the transaction
+	tx *sqlx.Tx
 }
 
 type MySqlConfig struct {
@@ -20,7 +25,8 @@ type MySqlConfig struct {
 	Database string
 }
 
-var MySql *MySqlDb
+// the last connection made, read from every worker goroutine so it is only ever swapped whole
+var connection atomic.Pointer[MySqlDb]
 
 func Connect(config *MySqlConfig) (*MySqlDb, error) {
 
@@ -45,20 +51,82 @@ func Connect(config *MySqlConfig) (*MySqlDb, error) {
 		return nil, fmt.Errorf("error connecting to database: %w", err)
 	}
 
-	MySql = &MySqlDb{client}
-	return MySql, nil
+	db := &MySqlDb{DB: client}
+	connection.Store(db)
+	return db, nil
 }
 
 func GetDb() (*MySqlDb, error) {
-	if MySql == nil {
+	db := connection.Load()
+	if db == nil {
 		return nil, errors.New("mysql not connected")
 	}
 
-	return MySql, nil
+	return db, nil
 }
 
 func (db *MySqlDb) Close() {
+	// the transaction copy shares the pool with the connection it came from
+	if db.tx != nil {
+		return
+	}
 	if db.DB != nil {
 		db.DB.Close()
 	}
 }
+
+// Begin returns a copy of the connection that runs every read and write in one transaction until Commit or Rollback
+func (db *MySqlDb) Begin() (*MySqlDb, error) {
+	if db.tx != nil {
+		return nil, errors.New("transaction already started")
+	}
+
+	tx, err := db.DB.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	return &MySqlDb{DB: db.DB, tx: tx}, nil
+}
+
+func (db *MySqlDb) Commit() error {
+	if db.tx == nil {
+		return errors.New("no transaction to commit")
+	}
+	return db.tx.Commit()
+}
+
+func (db *MySqlDb) Rollback() error {
+	if db.tx == nil {
+		return errors.New("no transaction to roll back")
+	}
+	return db.tx.Rollback()
+}
+
+func (db *MySqlDb) Exec(query string, args ...interface{}) (sql.Result, error) {
+	if db.tx != nil {
+		return db.tx.Exec(query, args...)
+	}
+	return db.DB.Exec(query, args...)
+}
+
+func (db *MySqlDb) Get(dest interface{}, query string, args ...interface{}) error {
+	if db.tx != nil {
+		return db.tx.Get(dest, query, args...)
+	}
+	return db.DB.Get(dest, query, args...)
+}
+
+func (db *MySqlDb) Select(dest interface{}, query string, args ...interface{}) error {
+	if db.tx != nil {
+		return db.tx.Select(dest, query, args...)
+	}
+	return db.DB.Select(dest, query, args...)
+}
+
+func (db *MySqlDb) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	if db.tx != nil {
+		return db.tx.Queryx(query, args...)
+	}
+	return db.DB.Queryx(query, args...)
+}
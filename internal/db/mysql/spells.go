@@ -2,9 +2,12 @@ package mysql
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/logging"
 )
 
 type DbSpell struct {
@@ -51,6 +54,30 @@ func (db *MySqlDb) GetSpell(id int) (DbSpell, error) {
 	return spell, nil
 }
 
+// GetSpells loads every spell in ids with one query, ids that are not in spell_dbc are left out
+func (db *MySqlDb) GetSpells(ids []int) ([]DbSpell, error) {
+	spells := []DbSpell{}
+	if len(ids) == 0 {
+		return spells, nil
+	}
+
+	sql := "SELECT " + GetSpellFields() + " FROM `spell_dbc` WHERE ID IN (" + IdList(ids) + ")"
+	if err := db.Select(&spells, sql); err != nil {
+		return nil, fmt.Errorf("failed to get %d spells: %v", len(ids), err)
+	}
+	return spells, nil
+}
+
+// IdList is ids joined for an IN (...), they are numbers so they go into the sql as they are and the
+// query does not run into the placeholder limit
+func IdList(ids []int) string {
+	list := make([]string, len(ids))
+	for i, id := range ids {
+		list[i] = strconv.Itoa(id)
+	}
+	return strings.Join(list, ", ")
+}
+
 func GetSpellFields() string {
 	return `
 	ID,
@@ -148,7 +175,19 @@ func (db *MySqlDb) WriteSpell(table string, spell DbSpell) error {
 		"EffectBonusMultiplier_3 = VALUES(EffectBonusMultiplier_3)"
 
 	// Execute the query with all the spell fields as parameters
-	_, err := db.Exec(sql,
+	_, err := db.Exec(sql, SpellWriteArgs(spell)...)
+
+	if err != nil {
+		slog.Error("failed to insert spell", "table", table, logging.Spell, spell.ID, "sql", sql)
+		return fmt.Errorf("failed to insert spell into %s: %w", table, err)
+	}
+
+	return nil
+}
+
+// SpellWriteArgs returns the spell values in the same order as GetSpellWriteFields for use as query parameters
+func SpellWriteArgs(spell DbSpell) []interface{} {
+	return []interface{}{
 		spell.ID,
 		spell.Name,
 		spell.Description,
@@ -173,14 +212,7 @@ func (db *MySqlDb) WriteSpell(table string, spell DbSpell) error {
 		spell.EffectBonusMultiplier1,
 		spell.EffectBonusMultiplier2,
 		spell.EffectBonusMultiplier3,
-	)
-
-	if err != nil {
-		log.Printf("Failed to run sql query: %v", sql)
-		return fmt.Errorf("failed to insert spell into %s: %w", table, err)
 	}
-
-	return nil
 }
 
 // CopySpell copies a spell from one table to another with an optional new ID
@@ -226,13 +258,10 @@ func (db *MySqlDb) CopySpell(sourceTable string, destTable string, spellId int,
 	sql = fmt.Sprintf("DROP TEMPORARY TABLE IF EXISTS %s", tempTableName)
 	_, err = db.Exec(sql)
 	if err != nil {
-		log.Printf("Warning: failed to drop temporary table %s: %v", tempTableName, err)
+		slog.Warn("failed to drop temporary table", "table", tempTableName, logging.Spell, spellId, "err", err)
 	}
 
-	log.Printf("Successfully copied spell %d to %s", spellId, destTable)
-	if newId > 0 && newId != spellId {
-		log.Printf("  with new ID: %d", newId)
-	}
+	slog.Debug("copied spell", logging.Spell, spellId, "table", destTable, "newId", newId)
 
 	return nil
 }
@@ -1,12 +1,13 @@
 package sqlite
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
-	"log"
 	"strings"
 
 	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/rng"
 )
 
 type HighLevelItem struct {
@@ -43,22 +44,26 @@ func (db *SqlLite) GetItem(entry int) (HighLevelItem, error) {
 	return item, nil
 }
 
-// This gets a random item that is close in stats type to the lower level items with some randomness
-func (db *SqlLite) GetRandItem(class, subclass int, statsList []int, end bool) (HighLevelItem, error) {
-	rndItem := HighLevelItem{}
+// This gets a random item that is close in stats type to the lower level items with some randomness,
+// the candidates are loaded in entry order and picked with source (nil is the shared rng) so a seeded run picks the same item
+func (db *SqlLite) GetRandItem(source *rng.Source, class, subclass int, statsList []int, end bool) (HighLevelItem, error) {
+	candidates := []HighLevelItem{}
 	var statsTxt string
 	var err error
-	var sql string
+	var query string
 
 	// if we have a stats_list try to match by that first, if not then just select a random item from the class and subclass
 	if len(statsList) == 0 {
-		sql = "SELECT * FROM items WHERE class = ? and subclass = ? and itemLevel >= 240  ORDER BY RANDOM() LIMIT 1"
-		err = db.Get(&rndItem, sql, class, subclass)
+		query = "SELECT * FROM items WHERE class = ? and subclass = ? and itemLevel >= 240 ORDER BY entry"
+		err = db.Select(&candidates, query, class, subclass)
 	} else {
 		// convert the array of ints to a commas string for lookup
 		statsTxt = intSliceToString(statsList)
-		sql = "SELECT * FROM items WHERE class = ? and subclass = ? and stats_list and itemLevel >= 240 like ? ORDER BY RANDOM() LIMIT 1"
-		err = db.Get(&rndItem, sql, class, subclass, statsTxt+"%")
+		query = "SELECT * FROM items WHERE class = ? and subclass = ? and stats_list and itemLevel >= 240 like ? ORDER BY entry"
+		err = db.Select(&candidates, query, class, subclass, statsTxt+"%")
+	}
+	if err == nil && len(candidates) == 0 {
+		err = sql.ErrNoRows
 	}
 
 	if err != nil {
@@ -70,22 +75,21 @@ func (db *SqlLite) GetRandItem(class, subclass int, statsList []int, end bool) (
 		}
 
 		// if there was not a remove the last stat and try again
-		if err.Error() == "sql: no rows in result set" {
+		if errors.Is(err, sql.ErrNoRows) {
 
 			if len(statsList) == 0 {
-				return db.GetRandItem(class, subclass, statsList, true)
+				return db.GetRandItem(source, class, subclass, statsList, true)
 			} else {
 				statsList = statsList[:len(statsList)-1]
-				return db.GetRandItem(class, subclass, statsList, false)
+				return db.GetRandItem(source, class, subclass, statsList, false)
 			}
 
 		}
 
-		log.Fatalf("Error getting random sql: %v error: %v", sql, err)
-		return HighLevelItem{}, err
+		return HighLevelItem{}, fmt.Errorf("failed to get a random item: %w", err)
 	}
 
-	return rndItem, nil
+	return candidates[source.IntN(len(candidates))], nil
 }
 
 func (db *SqlLite) GetItemFromDungeon(itemEntry int) (DungeonItem, error) {
@@ -100,6 +104,20 @@ func (db *SqlLite) GetItemFromDungeon(itemEntry int) (DungeonItem, error) {
 	return item, nil
 }
 
+// GetDungeonEntries is the entry of every dungeon item that passes where, a condition on dungeon_items
+// with ? placeholders for args
+func (db *SqlLite) GetDungeonEntries(where string, args []interface{}) ([]int, error) {
+	entries := []int{}
+	sql := "SELECT entry FROM dungeon_items WHERE " + where + " ORDER BY entry"
+
+	err := db.Select(&entries, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
 func intSliceToString(slice []int) string {
 	str := fmt.Sprint(slice)
 	str = strings.Trim(str, "[]")
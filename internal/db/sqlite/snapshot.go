@@ -0,0 +1,201 @@
+package sqlite
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+// Every world table the generator, raid-gear and emblem tools read from
+var SnapshotTables = []string{
+	"item_template",
+	"item_template_locale",
+	"spell_dbc",
+	"creature",
+	"creature_template",
+	"creature_loot_template",
+	"reference_loot_template",
+	"gameobject",
+	"gameobject_template",
+	"gameobject_loot_template",
+	"map_dbc",
+}
+
+// Extra indexes so the loot joins stay fast without the MySQL secondary keys
+var snapshotIndexes = map[string][]string{
+	"creature":                 {"map", "id1"},
+	"creature_loot_template":   {"Entry", "Reference"},
+	"reference_loot_template":  {"Entry", "Item"},
+	"gameobject":               {"map", "id"},
+	"gameobject_loot_template": {"Entry"},
+	"item_template":            {"name"},
+	"item_template_locale":     {"ID"},
+}
+
+const snapshotBatchSize = 5000
+
+type snapshotColumn struct {
+	Field   string  `db:"Field"`
+	Type    string  `db:"Type"`
+	Null    string  `db:"Null"`
+	Key     string  `db:"Key"`
+	Default *string `db:"Default"`
+	Extra   string  `db:"Extra"`
+}
+
+// CreateSnapshot copies the given world tables from MySQL into a new SQLite file at path.
+// An existing file at path is replaced so a snapshot always reflects a single point in time.
+func CreateSnapshot(src *mysql.MySqlDb, path string, tables []string) error {
+	if len(tables) == 0 {
+		tables = SnapshotTables
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove old snapshot %s: %w", path, err)
+	}
+
+	dest, err := sqlx.Open("sqlite3", path)
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot %s: %w", path, err)
+	}
+	defer dest.Close()
+
+	for _, table := range tables {
+		count, err := copyTable(src, dest, table)
+		if err != nil {
+			return fmt.Errorf("failed to snapshot %s: %w", table, err)
+		}
+		slog.Info("snapshot table", "table", table, "rows", count)
+	}
+
+	return nil
+}
+
+// create the table in sqlite using the mysql column definitions then stream all rows over
+func copyTable(src *mysql.MySqlDb, dest *sqlx.DB, table string) (int, error) {
+	columns := []snapshotColumn{}
+	if err := src.Unsafe().Select(&columns, "SHOW COLUMNS FROM "+table); err != nil {
+		return 0, fmt.Errorf("failed to read columns: %w", err)
+	}
+
+	if _, err := dest.Exec(createTableSql(table, columns)); err != nil {
+		return 0, fmt.Errorf("failed to create table: %w", err)
+	}
+
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = quoteIdent(col.Field)
+	}
+
+	rows, err := src.Queryx("SELECT " + strings.Join(names, ", ") + " FROM " + table)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read rows: %w", err)
+	}
+	defer rows.Close()
+
+	insert := "INSERT INTO " + quoteIdent(table) + " (" + strings.Join(names, ", ") + ") VALUES (" + placeholders(len(names)) + ")"
+
+	count := 0
+	tx, err := dest.Beginx()
+	if err != nil {
+		return 0, err
+	}
+	stmt, err := tx.Preparex(insert)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	for rows.Next() {
+		values, err := rows.SliceScan()
+		if err != nil {
+			tx.Rollback()
+			return count, err
+		}
+
+		// the mysql driver hands back raw bytes for text protocol results, store them as text so
+		// sqlite column affinity converts numbers back to integers and reals
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+
+		if _, err := stmt.Exec(values...); err != nil {
+			tx.Rollback()
+			return count, err
+		}
+		count++
+
+		if count%snapshotBatchSize == 0 {
+			if err := tx.Commit(); err != nil {
+				return count, err
+			}
+			if tx, err = dest.Beginx(); err != nil {
+				return count, err
+			}
+			if stmt, err = tx.Preparex(insert); err != nil {
+				tx.Rollback()
+				return count, err
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		tx.Rollback()
+		return count, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return count, err
+	}
+
+	for _, col := range snapshotIndexes[table] {
+		sql := fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_%s ON %s (%s)", table, strings.ToLower(col), quoteIdent(table), quoteIdent(col))
+		if _, err := dest.Exec(sql); err != nil {
+			return count, fmt.Errorf("failed to index %s: %w", col, err)
+		}
+	}
+
+	return count, nil
+}
+
+func createTableSql(table string, columns []snapshotColumn) string {
+	defs := []string{}
+	keys := []string{}
+	for _, col := range columns {
+		defs = append(defs, quoteIdent(col.Field)+" "+sqliteType(col.Type))
+		if col.Key == "PRI" {
+			keys = append(keys, quoteIdent(col.Field))
+		}
+	}
+
+	if len(keys) > 0 {
+		defs = append(defs, "PRIMARY KEY ("+strings.Join(keys, ", ")+")")
+	}
+
+	return "CREATE TABLE " + quoteIdent(table) + " (\n\t" + strings.Join(defs, ",\n\t") + "\n)"
+}
+
+// map a mysql column type onto the matching sqlite type affinity
+func sqliteType(mysqlType string) string {
+	t := strings.ToLower(mysqlType)
+	switch {
+	case strings.Contains(t, "int"):
+		return "INTEGER"
+	case strings.HasPrefix(t, "float"), strings.HasPrefix(t, "double"), strings.HasPrefix(t, "decimal"):
+		return "REAL"
+	case strings.Contains(t, "blob"), strings.Contains(t, "binary"):
+		return "BLOB"
+	default:
+		return "TEXT"
+	}
+}
+
+func quoteIdent(name string) string {
+	return "`" + name + "`"
+}
@@ -1,6 +1,9 @@
 package sqlite
 
 import (
+	"errors"
+	"sync/atomic"
+
 	"github.com/jmoiron/sqlx"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -10,7 +13,8 @@ type SqlLite struct {
 	*sqlx.DB
 }
 
-var SqlLiteDb *SqlLite
+// the last connection made, see mysql.GetDb
+var connection atomic.Pointer[SqlLite]
 
 func Connect(path string) (*SqlLite, error) {
 	client, err := sqlx.Open("sqlite3", path)
@@ -18,12 +22,17 @@ func Connect(path string) (*SqlLite, error) {
 		return nil, err
 	}
 
-	SqlLiteDb = &SqlLite{client}
-	return SqlLiteDb, nil
+	db := &SqlLite{client}
+	connection.Store(db)
+	return db, nil
 }
 
 func GetDb() (*SqlLite, error) {
-	return SqlLiteDb, nil
+	db := connection.Load()
+	if db == nil {
+		return nil, errors.New("sqlite not connected")
+	}
+	return db, nil
 }
 
 func (db *SqlLite) Close() {
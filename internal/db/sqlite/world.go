@@ -0,0 +1,593 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/config"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/ids"
+	"github.com/jmoiron/sqlx"
+)
+
+// WorldDb is a SQLite snapshot of the acore_world tables the generator reads, see CreateSnapshot.
+// It answers the same queries as mysql.MySqlDb so the generator can run without a world server.
+type WorldDb struct {
+	*sqlx.DB
+
+	// set on the copy returned by Begin, see mysql.MySqlDb
+	tx *sqlx.Tx
+}
+
+func OpenWorld(path string) (*WorldDb, error) {
+	client, err := sqlx.Open("sqlite3", path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening snapshot %s: %w", path, err)
+	}
+
+	// Verify the file is actually a snapshot before anything tries to query it
+	var count int
+	err = client.Get(&count, "SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'item_template'")
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("error reading snapshot %s: %w", path, err)
+	}
+	if count == 0 {
+		client.Close()
+		return nil, fmt.Errorf("snapshot %s does not contain an item_template table", path)
+	}
+
+	return &WorldDb{DB: client}, nil
+}
+
+func (db *WorldDb) Close() {
+	if db.tx != nil {
+		return
+	}
+	if db.DB != nil {
+		db.DB.Close()
+	}
+}
+
+// Begin returns a copy of the snapshot that runs every read and write in one transaction until Commit or Rollback
+func (db *WorldDb) Begin() (*WorldDb, error) {
+	if db.tx != nil {
+		return nil, errors.New("transaction already started")
+	}
+
+	tx, err := db.DB.Beginx()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	return &WorldDb{DB: db.DB, tx: tx}, nil
+}
+
+func (db *WorldDb) Commit() error {
+	if db.tx == nil {
+		return errors.New("no transaction to commit")
+	}
+	return db.tx.Commit()
+}
+
+func (db *WorldDb) Rollback() error {
+	if db.tx == nil {
+		return errors.New("no transaction to roll back")
+	}
+	return db.tx.Rollback()
+}
+
+func (db *WorldDb) Exec(query string, args ...interface{}) (sql.Result, error) {
+	if db.tx != nil {
+		return db.tx.Exec(query, args...)
+	}
+	return db.DB.Exec(query, args...)
+}
+
+func (db *WorldDb) Get(dest interface{}, query string, args ...interface{}) error {
+	if db.tx != nil {
+		return db.tx.Get(dest, query, args...)
+	}
+	return db.DB.Get(dest, query, args...)
+}
+
+func (db *WorldDb) Select(dest interface{}, query string, args ...interface{}) error {
+	if db.tx != nil {
+		return db.tx.Select(dest, query, args...)
+	}
+	return db.DB.Select(dest, query, args...)
+}
+
+func (db *WorldDb) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	if db.tx != nil {
+		return db.tx.Queryx(query, args...)
+	}
+	return db.DB.Queryx(query, args...)
+}
+
+func (db *WorldDb) GetItem(entry int) (mysql.DbItem, error) {
+	if entry == 0 {
+		return mysql.DbItem{}, fmt.Errorf("entry cannot be 0")
+	}
+
+	item := mysql.DbItem{}
+	sql := "SELECT " + mysql.GetItemFields("") + " FROM item_template WHERE entry = ?"
+	err := db.Get(&item, sql, entry)
+	if err != nil {
+		return mysql.DbItem{}, err
+	}
+
+	return item, nil
+}
+
+// GetAllItems is every item_template row below the generated items, see mysql.MySqlDb
+func (db *WorldDb) GetAllItems() ([]mysql.DbItem, error) {
+	items := []mysql.DbItem{}
+	sql := fmt.Sprintf("SELECT %s FROM item_template WHERE entry < %d ORDER BY entry", mysql.GetItemFields(""), ids.GeneratedBase(ids.Items))
+	if err := db.Select(&items, sql); err != nil {
+		return nil, fmt.Errorf("failed to get the items: %v", err)
+	}
+	return items, nil
+}
+
+// Look up a mythic item by name
+func (db *WorldDb) GetByNameAndDifficulty(name string, difficulty int) (mysql.DbItem, error) {
+	item := mysql.DbItem{}
+	var min, max int = 0, 0
+	if difficulty == 0 {
+		return mysql.DbItem{}, errors.New("difficulty cannot be 0")
+	}
+
+	if difficulty == 3 {
+		min = config.MythicItemLevelStart
+		max = config.MythicItemLevelEnd
+	}
+
+	if difficulty == 4 {
+		min = config.LegendaryItemLevelStart
+		max = config.LegendaryItemLevelEnd
+	}
+
+	if difficulty == 5 {
+		min = config.AscendantItemLevelStart
+		max = config.AscendantItemLevelEnd
+	}
+
+	sql := "SELECT " + mysql.GetItemFields("") + " FROM item_template WHERE name like ? and ItemLevel >= ? and ItemLevel < ? LIMIT 1"
+	name = "%" + name
+	err := db.Get(&item, sql, name, min, max)
+	if err != nil {
+		return mysql.DbItem{}, err
+	}
+
+	return item, nil
+}
+
+// returns all items from item_template where the quality is between rare and legendary items, where is
+// an extra condition on item_template with ? placeholders for args
+func (db *WorldDb) GetRarePlusItems(where string, args []interface{}, limit, offset int) ([]mysql.DbItem, error) {
+	items := []mysql.DbItem{}
+	sql := "SELECT " + mysql.GetItemFields("") + " FROM item_template WHERE Quality >= 3 and Quality <= 5 and (class = 2 or class = 4) "
+	sql += fmt.Sprintf("and subclass != 20 AND entry < %d", ids.GeneratedBase(ids.Items))
+	if where != "" {
+		sql += " AND (" + where + ")"
+	}
+	sql += " ORDER BY entry ASC" + limitClause(limit, offset)
+
+	err := db.Select(&items, sql, args...)
+	if err != nil {
+		return []mysql.DbItem{}, err
+	}
+
+	return items, nil
+}
+
+// limitClause is the LIMIT and OFFSET of a query, 0 is no limit or offset
+func limitClause(limit, offset int) string {
+	if limit == 0 && offset == 0 {
+		return ""
+	}
+	// an offset needs a limit, the largest one reads to the end
+	clause := " LIMIT -1"
+	if limit != 0 {
+		clause = fmt.Sprintf(" LIMIT %d", limit)
+	}
+	if offset != 0 {
+		clause += fmt.Sprintf(" OFFSET %d", offset)
+	}
+	return clause
+}
+
+func (db *WorldDb) GetBossMapItems(mapId int, bossEntries []int, gameObjectEntries []int, limit, offset int) ([]mysql.DbItem, error) {
+	items := []mysql.DbItem{}
+
+	bossEntriesCondition := ""
+	if len(bossEntries) > 0 {
+		bossEntriesCondition = fmt.Sprintf("OR ct.entry IN (%s)", intSliceToString(bossEntries))
+	}
+
+	gameObjectEntriesCondition := ""
+	if len(gameObjectEntries) > 0 {
+		gameObjectEntriesCondition = fmt.Sprintf("AND got.entry IN (%s)", intSliceToString(gameObjectEntries))
+	}
+
+	sql := `SELECT DISTINCT ` + mysql.GetItemFields("it") + `
+FROM creature_template ct
+LEFT JOIN creature c ON c.id1 = ct.entry
+LEFT JOIN map_dbc m ON c.map = m.ID
+LEFT JOIN creature_loot_template clt ON ct.lootid = clt.Entry
+LEFT JOIN reference_loot_template rlt ON clt.Reference = rlt.Entry
+LEFT JOIN item_template it ON rlt.Item = it.entry
+
+WHERE
+    ( m.ID = ? ` + bossEntriesCondition + ` )
+    AND ct.rank IN (3)
+    AND it.class IN (2, 4)
+    AND it.bonding IN (1, 2)
+    AND it.Quality >= 4`
+
+	if len(gameObjectEntries) > 0 {
+		sql += `
+
+UNION
+
+SELECT DISTINCT ` + mysql.GetItemFields("it") + `
+FROM gameobject go
+JOIN gameobject_template got ON go.id = got.entry
+LEFT JOIN gameobject_loot_template glt ON got.Data1 = glt.Entry
+LEFT JOIN reference_loot_template rlt ON glt.Reference = rlt.Entry
+LEFT JOIN item_template it ON rlt.Item = it.entry
+
+WHERE go.map = ?
+    ` + gameObjectEntriesCondition + `
+    AND it.class IN (2, 4)
+    AND it.bonding IN (1, 2)
+    AND it.Quality >= 4`
+	}
+
+	if limit != 0 && offset != 0 {
+		sql += fmt.Sprintf(" LIMIT %v OFFSET %v", limit, offset)
+	}
+
+	var args []interface{}
+	args = append(args, mapId)
+	if len(gameObjectEntries) > 0 {
+		args = append(args, mapId)
+	}
+
+	err := db.Select(&items, sql, args...)
+	if err != nil {
+		return []mysql.DbItem{}, err
+	}
+
+	return items, nil
+}
+
+func (db *WorldDb) GetRaidPhase1Items(class, subclass, limit, offset int) ([]mysql.DbItem, error) {
+	items := []mysql.DbItem{}
+
+	sql := `SELECT DISTINCT ` + mysql.GetItemFields("it") + `
+	FROM creature c
+	JOIN creature_template ct ON c.id1 = ct.entry
+	JOIN map_dbc m ON c.map = m.ID
+	LEFT JOIN creature_loot_template clt ON ct.lootid = clt.Entry
+	LEFT JOIN reference_loot_template rlt ON clt.Reference = rlt.Entry
+	LEFT JOIN item_template it ON rlt.Item = it.entry
+
+WHERE
+    m.ID IN (533,615,616)
+    AND ct.rank = 3
+    AND it.class = ?
+    AND it.subclass = ?
+    AND it.bonding IN (1, 2)
+    AND it.Quality >= 3
+`
+
+	if limit != 0 && offset != 0 {
+		sql += fmt.Sprintf(" LIMIT %v OFFSET %v", limit, offset)
+	}
+
+	err := db.Select(&items, sql, class, subclass)
+	if err != nil {
+		return []mysql.DbItem{}, err
+	}
+
+	return items, nil
+}
+
+// CopyItem copies an item from one snapshot table to another with an optional new entry
+func (db *WorldDb) CopyItem(sourceTable string, destTable string, itemEntry int, newEntry int) error {
+	return db.copyRow(sourceTable, destTable, "entry", itemEntry, newEntry)
+}
+
+// CopySpell copies a spell from one snapshot table to another with an optional new ID
+func (db *WorldDb) CopySpell(sourceTable string, destTable string, spellId int, newId int) error {
+	return db.copyRow(sourceTable, destTable, "ID", spellId, newId)
+}
+
+// copies a single row through a temporary table so every column comes along without listing them.
+// SQLite temp tables only live on one connection so the whole copy runs inside a transaction,
+// the one from Begin when there is one.
+func (db *WorldDb) copyRow(sourceTable, destTable, key string, id, newId int) error {
+	tempTableName := fmt.Sprintf("temp_copy_%d", time.Now().UnixNano())
+
+	tx := db.tx
+	if tx == nil {
+		var err error
+		tx, err = db.DB.Beginx()
+		if err != nil {
+			return fmt.Errorf("failed to start copy transaction: %w", err)
+		}
+		defer tx.Rollback()
+	}
+
+	sql := fmt.Sprintf("CREATE TEMP TABLE %s AS SELECT * FROM %s WHERE %s = %d", tempTableName, sourceTable, key, id)
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("failed to create temporary table: %w", err)
+	}
+
+	if newId > 0 && newId != id {
+		sql = fmt.Sprintf("UPDATE %s SET %s = %d", tempTableName, key, newId)
+		if _, err := tx.Exec(sql); err != nil {
+			return fmt.Errorf("failed to update %s in temporary table: %w", key, err)
+		}
+	}
+
+	sql = fmt.Sprintf("INSERT OR REPLACE INTO %s SELECT * FROM %s", destTable, tempTableName)
+	if _, err := tx.Exec(sql); err != nil {
+		return fmt.Errorf("failed to copy row from temporary table to destination: %w", err)
+	}
+
+	if _, err := tx.Exec("DROP TABLE " + tempTableName); err != nil {
+		slog.Warn("failed to drop temporary table", "table", tempTableName, "err", err)
+	}
+
+	if db.tx != nil {
+		return nil
+	}
+	return tx.Commit()
+}
+
+// WriteItem upserts the generator managed item_template columns, see mysql.MySqlDb.WriteItem
+func (db *WorldDb) WriteItem(table string, item mysql.DbItem) error {
+	fields := mysql.GetItemFields("")
+	sql := "INSERT INTO " + table + " (" + fields + ") VALUES (" + placeholders(len(mysql.ItemWriteArgs(item))) + ") " +
+		"ON CONFLICT(entry) DO UPDATE SET " +
+		"name = excluded.name, quality = excluded.quality, ItemLevel = excluded.ItemLevel, " +
+		"requiredLevel = excluded.requiredLevel, " +
+		"dmg_min1 = excluded.dmg_min1, dmg_max1 = excluded.dmg_max1, " +
+		"dmg_min2 = excluded.dmg_min2, dmg_max2 = excluded.dmg_max2, " +
+		"dmg_type1 = excluded.dmg_type1, dmg_type2 = excluded.dmg_type2, " +
+		"statsCount = excluded.statsCount, " +
+		"stat_type1 = excluded.stat_type1, stat_value1 = excluded.stat_value1, " +
+		"stat_type2 = excluded.stat_type2, stat_value2 = excluded.stat_value2, " +
+		"stat_type3 = excluded.stat_type3, stat_value3 = excluded.stat_value3, " +
+		"stat_type4 = excluded.stat_type4, stat_value4 = excluded.stat_value4, " +
+		"stat_type5 = excluded.stat_type5, stat_value5 = excluded.stat_value5, " +
+		"stat_type6 = excluded.stat_type6, stat_value6 = excluded.stat_value6, " +
+		"stat_type7 = excluded.stat_type7, stat_value7 = excluded.stat_value7, " +
+		"stat_type8 = excluded.stat_type8, stat_value8 = excluded.stat_value8, " +
+		"stat_type9 = excluded.stat_type9, stat_value9 = excluded.stat_value9, " +
+		"stat_type10 = excluded.stat_type10, stat_value10 = excluded.stat_value10, " +
+		"spellid_1 = excluded.spellid_1, spellid_2 = excluded.spellid_2, spellid_3 = excluded.spellid_3"
+
+	_, err := db.Exec(sql, mysql.ItemWriteArgs(item)...)
+	if err != nil {
+		return fmt.Errorf("failed to insert item into %s: %w", table, err)
+	}
+
+	return nil
+}
+
+// WriteSpell upserts the generator managed spell_dbc columns, see mysql.MySqlDb.WriteSpell
+func (db *WorldDb) WriteSpell(table string, spell mysql.DbSpell) error {
+	fields := mysql.GetSpellWriteFields()
+	sql := "INSERT INTO " + table + " (" + fields + ") VALUES (" + placeholders(24) + ") " +
+		"ON CONFLICT(ID) DO UPDATE SET " +
+		"Name_Lang_enUS = excluded.Name_Lang_enUS, " +
+		"Description_Lang_enUS = excluded.Description_Lang_enUS, " +
+		"AuraDescription_Lang_enUS = excluded.AuraDescription_Lang_enUS, " +
+		"ProcChance = excluded.ProcChance, SpellLevel = excluded.SpellLevel, " +
+		"Effect_1 = excluded.Effect_1, Effect_2 = excluded.Effect_2, Effect_3 = excluded.Effect_3, " +
+		"EffectDieSides_1 = excluded.EffectDieSides_1, EffectDieSides_2 = excluded.EffectDieSides_2, EffectDieSides_3 = excluded.EffectDieSides_3, " +
+		"EffectRealPointsPerLevel_1 = excluded.EffectRealPointsPerLevel_1, EffectRealPointsPerLevel_2 = excluded.EffectRealPointsPerLevel_2, " +
+		"EffectRealPointsPerLevel_3 = excluded.EffectRealPointsPerLevel_3, " +
+		"EffectBasePoints_1 = excluded.EffectBasePoints_1, EffectBasePoints_2 = excluded.EffectBasePoints_2, EffectBasePoints_3 = excluded.EffectBasePoints_3, " +
+		"EffectAura_1 = excluded.EffectAura_1, EffectAura_2 = excluded.EffectAura_2, EffectAura_3 = excluded.EffectAura_3, " +
+		"EffectBonusMultiplier_1 = excluded.EffectBonusMultiplier_1, EffectBonusMultiplier_2 = excluded.EffectBonusMultiplier_2, " +
+		"EffectBonusMultiplier_3 = excluded.EffectBonusMultiplier_3"
+
+	_, err := db.Exec(sql, mysql.SpellWriteArgs(spell)...)
+	if err != nil {
+		return fmt.Errorf("failed to insert spell into %s: %w", table, err)
+	}
+
+	return nil
+}
+
+func (db *WorldDb) GetSpell(id int) (mysql.DbSpell, error) {
+	if id == 0 {
+		return mysql.DbSpell{}, fmt.Errorf("id cannot be 0")
+	}
+
+	spell := mysql.DbSpell{}
+	sql := "SELECT " + mysql.GetSpellFields() + " FROM spell_dbc WHERE ID = ?"
+	err := db.Get(&spell, sql, id)
+	if err != nil {
+		return mysql.DbSpell{}, fmt.Errorf("failed to get spell: %v", err)
+	}
+
+	return spell, nil
+}
+
+// GetSpells loads every spell in ids with one query, see mysql.MySqlDb
+func (db *WorldDb) GetSpells(ids []int) ([]mysql.DbSpell, error) {
+	spells := []mysql.DbSpell{}
+	if len(ids) == 0 {
+		return spells, nil
+	}
+
+	sql := "SELECT " + mysql.GetSpellFields() + " FROM spell_dbc WHERE ID IN (" + mysql.IdList(ids) + ")"
+	if err := db.Select(&spells, sql); err != nil {
+		return nil, fmt.Errorf("failed to get %d spells: %v", len(ids), err)
+	}
+	return spells, nil
+}
+
+func (db *WorldDb) GetBosses(mapId int) ([]mysql.Boss, error) {
+	if mapId == 0 {
+		return nil, errors.New("mapId cannot be 0")
+	}
+
+	bosses := []mysql.Boss{}
+	var sql string
+
+	// 540 is pre-classic dungeons so XP Multiplier is best way to determine bosses / rare mobs
+	if mapId < 540 {
+		sql = `
+			SELECT ct.entry, ct.name, ct.ScriptName, ct.ExperienceModifier from creature c
+			JOIN creature_template ct ON(c.id1 = ct.entry) WHERE map = ? and ExperienceModifier >= 2
+		`
+	} else {
+		sql = `
+			SELECT ct.entry, ct.name, ct.ScriptName, ct.ExperienceModifier from creature c
+			JOIN creature_template ct ON(c.id1 = ct.entry) WHERE map = ? and ct.ScriptName Like 'boss_%'
+		`
+	}
+
+	err := db.Select(&bosses, sql, mapId)
+	if err != nil {
+		return nil, err
+	}
+
+	return bosses, nil
+}
+
+func (db *WorldDb) GetBossLoot(bossId int) ([]mysql.DbItem, error) {
+	if bossId == 0 {
+		return nil, errors.New("bossId cannot be 0")
+	}
+
+	items := []mysql.DbItem{}
+	sql := `
+	SELECT ` + mysql.GetItemFields("") + `
+	from item_template
+	where
+	entry in
+		(SELECT item from creature_loot_template where entry = ? and GroupId != 0 and Reference = 0)
+	and Quality > 2
+	`
+
+	udb := db.Unsafe()
+	err := udb.Select(&items, sql, bossId)
+	if err != nil {
+		return nil, err
+	}
+
+	var references []int
+	sql = `
+		SELECT reference
+		FROM creature_loot_template
+		WHERE entry = ? AND Reference != 0
+	`
+	err = db.Select(&references, sql, bossId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get references: %v sql %s", err, sql)
+	}
+
+	for _, ref := range references {
+		refItems := []mysql.DbItem{}
+		sql = `
+		SELECT ` + mysql.GetItemFields("it") + `
+		FROM reference_loot_template rlt
+		  JOIN item_template it ON rlt.Item = it.entry
+		WHERE rlt.Entry = ? and it.Quality > 2
+		`
+		err = db.Select(&refItems, sql, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ref items: %v sql %s", err, sql)
+		}
+
+		items = append(items, refItems...)
+	}
+
+	return items, nil
+}
+
+func (db *WorldDb) GetDungeons(expansionId int) ([]mysql.Dungeon, error) {
+	dungeons := []mysql.Dungeon{}
+
+	sql := `
+		SELECT ID as Id, MapName_Lang_enUS as Name, ExpansionID as ExpansionId
+		FROM map_dbc
+		WHERE InstanceType = 1 AND MapName_Lang_enUS NOT LIKE '%unused%'
+	`
+	var err error
+	if expansionId != -1 {
+		sql = sql + "AND ExpansionID = ?"
+		err = db.Select(&dungeons, sql, expansionId)
+	} else {
+		err = db.Select(&dungeons, sql)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dungeons %v", err)
+	}
+
+	for i := range dungeons {
+		dungeons[i].Level = mysql.DungeonLevel(dungeons[i].Id)
+	}
+
+	return dungeons, nil
+}
+
+// Gets a list of other rare+ items that drop in a specific instance.
+func (db *WorldDb) GetAddlDungeonDrops(instanceId int) ([]mysql.DbItem, error) {
+	fields := mysql.GetItemFields("it")
+	var items []mysql.DbItem
+	sql := `
+	SELECT ` + fields + `
+from
+    map_dbc m
+    join creature c on m.ID = c.map
+    join creature_template ct on c.id1 = ct.entry
+    left join creature_loot_template clt on ct.lootid = clt.Entry
+    left join reference_loot_template rlt on clt.Reference = rlt.Entry
+    left join item_template it on rlt.Item = it.entry
+WHERE m.ID = ? and Quality >= 3 and it.bonding = 2 and class IN(2,4)
+UNION
+SELECT ` + fields + `
+from
+    map_dbc m
+    join creature c on m.ID = c.map
+    join creature_template ct on c.id1 = ct.entry
+    left join creature_loot_template clt on clt.Entry = ct.Entry
+    left join item_template it on clt.Item = it.entry
+WHERE m.ID = ? and Quality >= 3 and it.bonding = 2 and it.class IN(2,4)
+UNION
+SELECT ` + fields + `
+from
+    map_dbc m
+    join gameobject go on m.ID = go.map
+    left join gameobject_template got on go.id = got.entry
+    left join gameobject_loot_template glt on glt.Entry = got.Data1
+    left join reference_loot_template rlt on glt.Reference = rlt.Entry
+    left join item_template it on rlt.Item = it.entry
+where m.ID = ? and Quality >=3 and it.bonding IN(1,2) and it.class IN(2,4)
+	`
+
+	err := db.Select(&items, sql, instanceId, instanceId, instanceId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get additional dungeon items: %v ", err)
+	}
+
+	return items, nil
+}
+
+func placeholders(count int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", count), ", ")
+}
@@ -0,0 +1,189 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/dbtest"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestWorld(t *testing.T) *WorldDb {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "world.db")
+	client, err := sqlx.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	setup := []string{
+		dbtest.TableSql("item_template", mysql.DbItem{}),
+		dbtest.TableSql("spell_dbc", mysql.DbSpell{}),
+		"INSERT INTO item_template (entry, name, Quality, class, subclass, ItemLevel) VALUES (100, 'Rare Sword', 3, 2, 7, 60)",
+		"INSERT INTO item_template (entry, name, Quality, class, subclass, ItemLevel) VALUES (101, 'Epic Helm', 4, 4, 4, 70)",
+		"INSERT INTO item_template (entry, name, Quality, class, subclass, ItemLevel) VALUES (102, 'Common Bread', 1, 0, 5, 1)",
+		"INSERT INTO item_template (entry, name, Quality, class, subclass, ItemLevel) VALUES (103, 'Fishing Pole', 3, 2, 20, 10)",
+		"INSERT INTO spell_dbc (ID, Name_Lang_enUS, EffectBasePoints_1) VALUES (7597, 'Increased Critical 1', 0)",
+	}
+	for _, sql := range setup {
+		if _, err := client.Exec(sql); err != nil {
+			t.Fatalf("setup failed %q: %v", sql, err)
+		}
+	}
+	client.Close()
+
+	world, err := OpenWorld(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(world.Close)
+
+	return world
+}
+
+func TestOpenWorldRequiresItemTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.db")
+	if _, err := OpenWorld(path); err == nil {
+		t.Error("expected error opening a file without item_template")
+	}
+}
+
+func TestWorldGetItem(t *testing.T) {
+	world := newTestWorld(t)
+
+	item, err := world.GetItem(101)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Name != "Epic Helm" || item.ItemLevel == nil || *item.ItemLevel != 70 {
+		t.Errorf("unexpected item %+v", item)
+	}
+
+	if _, err := world.GetItem(0); err == nil {
+		t.Error("expected error for entry 0")
+	}
+	if _, err := world.GetItem(999); err == nil {
+		t.Error("expected error for missing entry")
+	}
+}
+
+func TestWorldGetRarePlusItems(t *testing.T) {
+	world := newTestWorld(t)
+
+	tests := []struct {
+		name          string
+		where         string
+		args          []interface{}
+		limit, offset int
+		expected      []int
+	}{
+		{"all", "", nil, 0, 0, []int{100, 101}},
+		{"where", "class = ? AND subclass IN (?,?)", []interface{}{4, 3, 4}, 0, 0, []int{101}},
+		{"limit", "", nil, 1, 0, []int{100}},
+		{"offset", "", nil, 0, 1, []int{101}},
+		{"limit and offset", "", nil, 1, 1, []int{101}},
+	}
+
+	for _, tt := range tests {
+		items, err := world.GetRarePlusItems(tt.where, tt.args, tt.limit, tt.offset)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		entries := []int{}
+		for _, item := range items {
+			entries = append(entries, item.Entry)
+		}
+		if !reflect.DeepEqual(entries, tt.expected) {
+			t.Errorf("%s: got entries %v, want %v", tt.name, entries, tt.expected)
+		}
+	}
+}
+
+func TestWorldSpellCopyAndWrite(t *testing.T) {
+	world := newTestWorld(t)
+
+	if err := world.CopySpell("spell_dbc", "spell_dbc", 7597, 30007597); err != nil {
+		t.Fatal(err)
+	}
+
+	spell, err := world.GetSpell(30007597)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spell.Name != "Increased Critical 1" {
+		t.Errorf("copied spell name = %q", spell.Name)
+	}
+
+	spell.EffectBasePoints1 = 4
+	if err := world.WriteSpell("spell_dbc", spell); err != nil {
+		t.Fatal(err)
+	}
+
+	spell, err = world.GetSpell(30007597)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spell.EffectBasePoints1 != 4 {
+		t.Errorf("EffectBasePoints1 = %d, want 4", spell.EffectBasePoints1)
+	}
+}
+
+func TestWorldTransaction(t *testing.T) {
+	world := newTestWorld(t)
+
+	for _, commit := range []bool{false, true} {
+		tx, err := world.Begin()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := tx.CopyItem("item_template", "item_template", 100, 20000100); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tx.GetItem(20000100); err != nil {
+			t.Errorf("copy is not visible inside the transaction: %v", err)
+		}
+
+		if commit {
+			err = tx.Commit()
+		} else {
+			err = tx.Rollback()
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = world.GetItem(20000100)
+		if commit && err != nil {
+			t.Errorf("committed copy is missing: %v", err)
+		}
+		if !commit && err == nil {
+			t.Error("rolled back copy is still there")
+		}
+	}
+}
+
+func TestWorldBulkReads(t *testing.T) {
+	world := newTestWorld(t)
+
+	spells, err := world.GetSpells([]int{7597, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spells) != 1 || spells[0].ID != 7597 {
+		t.Errorf("expected only spell 7597, got %v", spells)
+	}
+
+	all, err := world.GetAllItems()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 4 || all[0].Entry != 100 {
+		t.Errorf("expected the 4 items in entry order, got %d", len(all))
+	}
+}
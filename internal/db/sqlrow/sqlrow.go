@@ -0,0 +1,251 @@
+package sqlrow
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Querier is the world database read directly, for the id checks, taken names, revert script and whole
+// rows. *mysql.MySqlDb and *sqlite.WorldDb both fit.
+type Querier interface {
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+	Queryx(query string, args ...interface{}) (*sqlx.Rows, error)
+}
+
+// Row is every column of one table row in table order
+type Row struct {
+	Columns []string
+	Values  []interface{}
+}
+
+// Read loads the row table.key = id, false when there is none
+func Read(db Querier, table, key string, id int) (Row, bool, error) {
+	rows, err := db.Queryx(fmt.Sprintf("SELECT * FROM %s WHERE %s = ?", table, key), id)
+	if err != nil {
+		return Row{}, false, fmt.Errorf("failed to read %s %s %d: %w", table, key, id, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return Row{}, false, rows.Err()
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return Row{}, false, err
+	}
+	values, err := rows.SliceScan()
+	if err != nil {
+		return Row{}, false, fmt.Errorf("failed to read %s %s %d: %w", table, key, id, err)
+	}
+	return Row{Columns: columns, Values: values}, true, nil
+}
+
+// ReadAll loads every row with table.key = id, for tables with more than one row per key
+func ReadAll(db Querier, table, key string, id int) ([]Row, error) {
+	rows, err := db.Queryx(fmt.Sprintf("SELECT * FROM %s WHERE %s = ?", table, key), id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s %s %d: %w", table, key, id, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	list := []Row{}
+	for rows.Next() {
+		values, err := rows.SliceScan()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s %s %d: %w", table, key, id, err)
+		}
+		list = append(list, Row{Columns: columns, Values: values})
+	}
+	return list, rows.Err()
+}
+
+// Set changes a column, names are matched ignoring case like MySQL does
+func (r Row) Set(column string, value interface{}) error {
+	for i, name := range r.Columns {
+		if strings.EqualFold(name, column) {
+			r.Values[i] = value
+			return nil
+		}
+	}
+	return fmt.Errorf("row has no column %s", column)
+}
+
+func (r Row) Get(column string) (interface{}, bool) {
+	for i, name := range r.Columns {
+		if strings.EqualFold(name, column) {
+			return r.Values[i], true
+		}
+	}
+	return nil, false
+}
+
+// ColumnList is the quoted column names joined for an INSERT
+func (r Row) ColumnList() string {
+	names := make([]string, len(r.Columns))
+	for i := range r.Columns {
+		names[i] = QuoteIdent(r.Columns[i])
+	}
+	return strings.Join(names, ", ")
+}
+
+// ValueList is the literal values joined for an INSERT
+func (r Row) ValueList() string {
+	literals := make([]string, len(r.Values))
+	for i := range r.Values {
+		literals[i] = Literal(r.Values[i])
+	}
+	return strings.Join(literals, ", ")
+}
+
+func QuoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// Literal is the sql for a value scanned by the mysql or sqlite driver or set by the generator
+func Literal(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case []byte:
+		return Quote(string(v))
+	case string:
+		return Quote(v)
+	case time.Time:
+		return Quote(v.Format("2006-01-02 15:04:05"))
+	default:
+		return Quote(fmt.Sprint(v))
+	}
+}
+
+func Quote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, "'", "''")
+	return "'" + value + "'"
+}
+
+// Batch writes full rows of one table as multi row INSERTs, each preceded by a DELETE of the same keys
+// so the script works on a database that has the rows already or never had the source rows
+type Batch struct {
+	Table string
+	Key   string
+	Size  int // keys per INSERT
+
+	// Unique are the other columns of the primary key of tables with several rows per key, like the
+	// locale of item_template_locale. All rows of one key go into the same INSERT.
+	Unique []string
+
+	w     io.Writer
+	rows  []Row
+	index map[string]int
+	keys  map[string]bool
+	err   error
+}
+
+func NewBatch(w io.Writer, table, key string, size int) *Batch {
+	if size < 1 {
+		size = 1
+	}
+	return &Batch{
+		Table: table,
+		Key:   key,
+		Size:  size,
+		w:     w,
+		index: map[string]int{},
+		keys:  map[string]bool{},
+	}
+}
+
+// Add queues a row and writes the batch once it is full. A row with a key already in the batch replaces it.
+func (b *Batch) Add(row Row) error {
+	if b.err != nil {
+		return b.err
+	}
+	key, ok := row.Get(b.Key)
+	if !ok {
+		return fmt.Errorf("%s row has no %s column", b.Table, b.Key)
+	}
+	if len(b.rows) > 0 && row.ColumnList() != b.rows[0].ColumnList() {
+		return fmt.Errorf("%s row %v has other columns than the rows before it", b.Table, key)
+	}
+
+	id := Literal(key)
+	unique := id
+	for _, column := range b.Unique {
+		value, ok := row.Get(column)
+		if !ok {
+			return fmt.Errorf("%s row %v has no %s column", b.Table, key, column)
+		}
+		unique += "/" + Literal(value)
+	}
+	if i, ok := b.index[unique]; ok {
+		b.rows[i] = row
+		return nil
+	}
+
+	// the next key starts a new INSERT once this one is full, the DELETE of a later INSERT would
+	// otherwise remove rows of the same key written by an earlier one
+	if !b.keys[id] && len(b.keys) >= b.Size {
+		if err := b.Flush(); err != nil {
+			return err
+		}
+	}
+	b.index[unique] = len(b.rows)
+	b.keys[id] = true
+	b.rows = append(b.rows, row)
+	return nil
+}
+
+// Flush writes whatever is queued
+func (b *Batch) Flush() error {
+	if b.err != nil || len(b.rows) == 0 {
+		return b.err
+	}
+
+	keys := []string{}
+	values := make([]string, len(b.rows))
+	seen := map[string]bool{}
+	for i, row := range b.rows {
+		key, _ := row.Get(b.Key)
+		if id := Literal(key); !seen[id] {
+			seen[id] = true
+			keys = append(keys, id)
+		}
+		values[i] = "(" + row.ValueList() + ")"
+	}
+
+	out := new(strings.Builder)
+	fmt.Fprintf(out, "DELETE FROM %s WHERE %s IN (%s);\n", b.Table, QuoteIdent(b.Key), strings.Join(keys, ", "))
+	fmt.Fprintf(out, "INSERT INTO %s (%s) VALUES\n%s;\n\n", b.Table, b.rows[0].ColumnList(), strings.Join(values, ",\n"))
+
+	b.rows = nil
+	b.index = map[string]int{}
+	b.keys = map[string]bool{}
+	if _, err := io.WriteString(b.w, out.String()); err != nil {
+		b.err = err
+	}
+	return b.err
+}
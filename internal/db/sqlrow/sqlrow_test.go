@@ -0,0 +1,98 @@
+package sqlrow
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestBatch(t *testing.T) {
+	out := new(bytes.Buffer)
+	batch := NewBatch(out, "item_template", "entry", 2)
+
+	row := func(entry int, name string) Row {
+		return Row{Columns: []string{"entry", "name"}, Values: []interface{}{entry, name}}
+	}
+	for _, r := range []Row{row(1, "Sword"), row(1, "Mythic Sword"), row(2, "Hunter's Bow"), row(3, "Axe")} {
+		if err := batch.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := batch.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "DELETE FROM item_template WHERE `entry` IN (1, 2);\n" +
+		"INSERT INTO item_template (`entry`, `name`) VALUES\n(1, 'Mythic Sword'),\n(2, 'Hunter''s Bow');\n\n" +
+		"DELETE FROM item_template WHERE `entry` IN (3);\n" +
+		"INSERT INTO item_template (`entry`, `name`) VALUES\n(3, 'Axe');\n\n"
+	if out.String() != expected {
+		t.Errorf("batch =\n%s\nwant\n%s", out.String(), expected)
+	}
+
+	other := Row{Columns: []string{"entry"}, Values: []interface{}{4}}
+	if err := batch.Add(row(5, "Mace")); err != nil {
+		t.Fatal(err)
+	}
+	if err := batch.Add(other); err == nil {
+		t.Error("expected a row with other columns to be refused")
+	}
+}
+
+func TestBatchUnique(t *testing.T) {
+	out := new(bytes.Buffer)
+	batch := NewBatch(out, "item_template_locale", "ID", 1)
+	batch.Unique = []string{"locale"}
+
+	row := func(id int, locale, name string) Row {
+		return Row{Columns: []string{"ID", "locale", "Name"}, Values: []interface{}{id, locale, name}}
+	}
+	for _, r := range []Row{row(1, "deDE", "Schwert"), row(1, "frFR", "Épée"), row(1, "frFR", "Lame"), row(2, "deDE", "Axt")} {
+		if err := batch.Add(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := batch.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	// every locale of a key stays in the INSERT after its DELETE
+	expected := "DELETE FROM item_template_locale WHERE `ID` IN (1);\n" +
+		"INSERT INTO item_template_locale (`ID`, `locale`, `Name`) VALUES\n(1, 'deDE', 'Schwert'),\n(1, 'frFR', 'Lame');\n\n" +
+		"DELETE FROM item_template_locale WHERE `ID` IN (2);\n" +
+		"INSERT INTO item_template_locale (`ID`, `locale`, `Name`) VALUES\n(2, 'deDE', 'Axt');\n\n"
+	if out.String() != expected {
+		t.Errorf("batch =\n%s\nwant\n%s", out.String(), expected)
+	}
+}
+
+func TestReadAndSet(t *testing.T) {
+	db, err := sqlx.Open("sqlite3", t.TempDir()+"/world.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	db.MustExec("CREATE TABLE spell_dbc (ID INTEGER PRIMARY KEY, Name_Lang_enUS TEXT, EffectBasePoints_1 INTEGER)")
+	db.MustExec("INSERT INTO spell_dbc VALUES (7597, 'Increased Critical 1', 0)")
+
+	row, found, err := Read(db, "spell_dbc", "ID", 7597)
+	if err != nil || !found {
+		t.Fatalf("expected spell 7597, got %v %v", found, err)
+	}
+	if err := row.Set("effectbasepoints_1", 12); err != nil {
+		t.Fatal(err)
+	}
+	if err := row.Set("Missing", 1); err == nil {
+		t.Error("expected a missing column to fail")
+	}
+	if values := row.ValueList(); values != "7597, 'Increased Critical 1', 12" {
+		t.Errorf("unexpected values %s", values)
+	}
+
+	if _, found, err := Read(db, "spell_dbc", "ID", 1); found || err != nil {
+		t.Errorf("expected no row, got %v %v", found, err)
+	}
+}
@@ -0,0 +1,192 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+)
+
+// Cache is a Store that keeps every item and spell it has read. A spell shared by thousands of items
+// is read once, and the spells of a whole list of items can be loaded up front with one query.
+// Items are handed out as clones so changing one does not change the cache. Everything else goes
+// straight to the store underneath.
+type Cache struct {
+	Store
+
+	mu      sync.RWMutex
+	items   map[int]mysql.DbItem
+	spells  map[int]mysql.DbSpell
+	missing map[int]bool // spells a bulk load did not find
+
+	// GetRaidPhase1Items is called with the same class and subclass for every raid item
+	raidItems map[[4]int][]mysql.DbItem
+}
+
+func NewCache(s Store) *Cache {
+	return &Cache{
+		Store:     s,
+		items:     map[int]mysql.DbItem{},
+		spells:    map[int]mysql.DbSpell{},
+		missing:   map[int]bool{},
+		raidItems: map[[4]int][]mysql.DbItem{},
+	}
+}
+
+func (c *Cache) GetItem(entry int) (mysql.DbItem, error) {
+	c.mu.RLock()
+	item, ok := c.items[entry]
+	c.mu.RUnlock()
+	if ok {
+		return item.Clone(), nil
+	}
+
+	item, err := c.Store.GetItem(entry)
+	if err != nil {
+		return mysql.DbItem{}, err
+	}
+	c.mu.Lock()
+	c.items[entry] = item.Clone()
+	c.mu.Unlock()
+	return item, nil
+}
+
+func (c *Cache) GetSpell(id int) (mysql.DbSpell, error) {
+	c.mu.RLock()
+	spell, ok := c.spells[id]
+	missing := c.missing[id]
+	c.mu.RUnlock()
+	if ok {
+		return spell, nil
+	}
+	if missing {
+		return mysql.DbSpell{}, fmt.Errorf("failed to get spell: %d is not in spell_dbc", id)
+	}
+
+	spell, err := c.Store.GetSpell(id)
+	if err != nil {
+		return mysql.DbSpell{}, err
+	}
+	c.mu.Lock()
+	c.spells[id] = spell
+	c.mu.Unlock()
+	return spell, nil
+}
+
+// GetSpells is the spells of ids that are in spell_dbc, the ones not read yet are loaded with one query
+func (c *Cache) GetSpells(ids []int) ([]mysql.DbSpell, error) {
+	if err := c.LoadSpells(ids); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	spells := []mysql.DbSpell{}
+	for _, id := range ids {
+		if spell, ok := c.spells[id]; ok {
+			spells = append(spells, spell)
+		}
+	}
+	return spells, nil
+}
+
+// LoadSpells reads every spell of ids the cache does not have yet with one query
+func (c *Cache) LoadSpells(ids []int) error {
+	c.mu.RLock()
+	load := []int{}
+	seen := map[int]bool{}
+	for _, id := range ids {
+		if id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if _, ok := c.spells[id]; !ok && !c.missing[id] {
+			load = append(load, id)
+		}
+	}
+	c.mu.RUnlock()
+	if len(load) == 0 {
+		return nil
+	}
+
+	spells, err := c.Store.GetSpells(load)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, spell := range spells {
+		c.spells[spell.ID] = spell
+	}
+	for _, id := range load {
+		if _, ok := c.spells[id]; !ok {
+			c.missing[id] = true
+		}
+	}
+	return nil
+}
+
+// LoadSpellsOf reads the spells of every item in one query, see LoadSpells
+func (c *Cache) LoadSpellsOf(items []mysql.DbItem) error {
+	ids := []int{}
+	for _, item := range items {
+		for _, id := range []*int{item.SpellId1, item.SpellId2, item.SpellId3} {
+			if id != nil {
+				ids = append(ids, *id)
+			}
+		}
+	}
+	return c.LoadSpells(ids)
+}
+
+// LoadItems preloads the whole item catalog below the generated items and every spell on it, two
+// queries instead of one per item for tools that read most of item_template anyway
+func (c *Cache) LoadItems() error {
+	items, err := c.Store.GetAllItems()
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	for _, item := range items {
+		c.items[item.Entry] = item
+	}
+	c.mu.Unlock()
+	return c.LoadSpellsOf(items)
+}
+
+// GetRaidPhase1Items reads each class and subclass once, the spells of the candidates come with them
+func (c *Cache) GetRaidPhase1Items(class, subclass, limit, offset int) ([]mysql.DbItem, error) {
+	key := [4]int{class, subclass, limit, offset}
+	c.mu.RLock()
+	list, ok := c.raidItems[key]
+	c.mu.RUnlock()
+
+	if !ok {
+		var err error
+		list, err = c.Store.GetRaidPhase1Items(class, subclass, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.LoadSpellsOf(list); err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.raidItems[key] = list
+		c.mu.Unlock()
+	}
+
+	clones := make([]mysql.DbItem, len(list))
+	for i, item := range list {
+		clones[i] = item.Clone()
+	}
+	return clones, nil
+}
+
+// Len is how many items and spells are cached
+func (c *Cache) Len() (items, spells int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.items), len(c.spells)
+}
@@ -0,0 +1,147 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+)
+
+// countingStore answers from maps and counts the queries, methods the cache does not use are left nil
+type countingStore struct {
+	Store
+	items   map[int]mysql.DbItem
+	spells  map[int]mysql.DbSpell
+	queries int
+}
+
+func (s *countingStore) GetItem(entry int) (mysql.DbItem, error) {
+	s.queries++
+	if item, ok := s.items[entry]; ok {
+		return item.Clone(), nil
+	}
+	return mysql.DbItem{}, fmt.Errorf("no item %d", entry)
+}
+
+func (s *countingStore) GetAllItems() ([]mysql.DbItem, error) {
+	s.queries++
+	list := []mysql.DbItem{}
+	for _, item := range s.items {
+		list = append(list, item.Clone())
+	}
+	return list, nil
+}
+
+func (s *countingStore) GetRaidPhase1Items(class, subclass, limit, offset int) ([]mysql.DbItem, error) {
+	return s.GetAllItems()
+}
+
+func (s *countingStore) GetSpell(id int) (mysql.DbSpell, error) {
+	s.queries++
+	if spell, ok := s.spells[id]; ok {
+		return spell, nil
+	}
+	return mysql.DbSpell{}, fmt.Errorf("no spell %d", id)
+}
+
+func (s *countingStore) GetSpells(ids []int) ([]mysql.DbSpell, error) {
+	s.queries++
+	list := []mysql.DbSpell{}
+	for _, id := range ids {
+		if spell, ok := s.spells[id]; ok {
+			list = append(list, spell)
+		}
+	}
+	return list, nil
+}
+
+func newCountingStore() *countingStore {
+	spell1, spell2, quality := 7597, 9331, 4
+	return &countingStore{
+		items: map[int]mysql.DbItem{
+			100: {Entry: 100, Name: "Sword", Quality: &quality, SpellId1: &spell1},
+			101: {Entry: 101, Name: "Helm", Quality: &quality, SpellId1: &spell1, SpellId2: &spell2},
+		},
+		spells: map[int]mysql.DbSpell{
+			7597: {ID: 7597, Name: "Increased Critical 1"},
+			9331: {ID: 9331, Name: "Increased Attack Power 26"},
+		},
+	}
+}
+
+func TestCacheReadsOnce(t *testing.T) {
+	world := newCountingStore()
+	cache := NewCache(world)
+
+	for i := 0; i < 3; i++ {
+		if _, err := cache.GetItem(100); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cache.GetSpell(7597); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if world.queries != 2 {
+		t.Errorf("expected one query per item and spell, got %d", world.queries)
+	}
+
+	// handed out items are copies
+	item, _ := cache.GetItem(100)
+	*item.Quality = 5
+	if again, _ := cache.GetItem(100); *again.Quality != 4 {
+		t.Errorf("changing an item changed the cache, quality is %d", *again.Quality)
+	}
+}
+
+func TestCacheLoadsSpellsOfItems(t *testing.T) {
+	world := newCountingStore()
+	cache := NewCache(world)
+
+	candidates, err := cache.GetRaidPhase1Items(4, 4, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates) != 2 || world.queries != 2 {
+		t.Fatalf("expected the candidates and their spells in 2 queries, got %d items in %d", len(candidates), world.queries)
+	}
+
+	for _, id := range []int{7597, 9331, 7597} {
+		if _, err := cache.GetSpell(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := cache.GetRaidPhase1Items(4, 4, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if world.queries != 2 {
+		t.Errorf("expected the spells and candidates from the cache, got %d queries", world.queries)
+	}
+
+	// a spell a bulk load did not find is not asked for again
+	if err := cache.LoadSpells([]int{12345}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.GetSpell(12345); err == nil {
+		t.Error("expected an error for a spell that is not there")
+	}
+	if world.queries != 3 {
+		t.Errorf("expected the missing spell to be read once, got %d queries", world.queries)
+	}
+}
+
+func TestCacheLoadItems(t *testing.T) {
+	world := newCountingStore()
+	cache := NewCache(world)
+
+	if err := cache.LoadItems(); err != nil {
+		t.Fatal(err)
+	}
+	if items, spells := cache.Len(); items != 2 || spells != 2 {
+		t.Errorf("expected 2 items and 2 spells, got %d and %d", items, spells)
+	}
+	cache.GetItem(101)
+	cache.GetSpell(9331)
+	if world.queries != 2 {
+		t.Errorf("expected the catalog and its spells in 2 queries, got %d", world.queries)
+	}
+}
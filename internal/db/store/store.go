@@ -0,0 +1,118 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlite"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlrow"
+)
+
+// ItemStore is every item_template query the generator runs against the world database
+type ItemStore interface {
+	GetItem(entry int) (mysql.DbItem, error)
+	GetAllItems() ([]mysql.DbItem, error)
+	GetByNameAndDifficulty(name string, difficulty int) (mysql.DbItem, error)
+	GetRarePlusItems(where string, args []interface{}, limit, offset int) ([]mysql.DbItem, error)
+	GetBossMapItems(mapId int, bossEntries []int, gameObjectEntries []int, limit, offset int) ([]mysql.DbItem, error)
+	GetRaidPhase1Items(class, subclass, limit, offset int) ([]mysql.DbItem, error)
+	CopyItem(sourceTable string, destTable string, itemEntry int, newEntry int) error
+	WriteItem(table string, item mysql.DbItem) error
+}
+
+// SpellStore is every spell_dbc query the generator runs against the world database
+type SpellStore interface {
+	GetSpell(id int) (mysql.DbSpell, error)
+	GetSpells(ids []int) ([]mysql.DbSpell, error)
+	CopySpell(sourceTable string, destTable string, spellId int, newId int) error
+	WriteSpell(table string, spell mysql.DbSpell) error
+}
+
+// WorldStore covers the creature, loot and map lookups used to crawl dungeons
+type WorldStore interface {
+	GetBosses(mapId int) ([]mysql.Boss, error)
+	GetBossLoot(bossId int) ([]mysql.DbItem, error)
+	GetDungeons(expansionId int) ([]mysql.Dungeon, error)
+	GetAddlDungeonDrops(instanceId int) ([]mysql.DbItem, error)
+}
+
+// Store is a full world database, either a live MySQL server or a SQLite snapshot of one
+type Store interface {
+	ItemStore
+	SpellStore
+	WorldStore
+	Close()
+}
+
+// Tx is a Store that runs every read and write in one transaction
+type Tx interface {
+	Store
+	sqlrow.Querier
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Commit() error
+	Rollback() error
+}
+
+// current is set once at startup and read by every worker scaling items, see Use
+var (
+	mu      sync.RWMutex
+	current Store
+)
+
+// Begin starts a transaction on the MySQL world database or a snapshot
+func Begin(s Store) (Tx, error) {
+	switch db := s.(type) {
+	case *mysql.MySqlDb:
+		return db.Begin()
+	case *sqlite.WorldDb:
+		return db.Begin()
+	default:
+		return nil, errors.New("store does not support transactions")
+	}
+}
+
+// Open connects to the SQLite snapshot at snapshotPath, or to MySQL using the DB_* env vars when the
+// path is empty. The opened store becomes the one returned by GetStore.
+func Open(snapshotPath string) (Store, error) {
+	var s Store
+	var err error
+
+	if snapshotPath != "" {
+		s, err = sqlite.OpenWorld(snapshotPath)
+	} else {
+		s, err = mysql.Connect(nil)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	Use(s)
+	return s, nil
+}
+
+// Use sets the store that items and spells read through
+func Use(s Store) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	current = s
+}
+
+// GetStore returns the active store, falling back to the MySQL connection for tools that only call mysql.Connect
+func GetStore() (Store, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current != nil {
+		return current, nil
+	}
+
+	if db, err := mysql.GetDb(); err == nil {
+		return db, nil
+	}
+
+	return nil, errors.New("no world database connected")
+}
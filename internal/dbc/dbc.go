@@ -0,0 +1,278 @@
+// Package dbc reads and writes WotLK (3.3.5) WDBC client database files from a declared schema.
+// Records keep their raw values and the original string block is kept as is, so a file that is
+// read and written back without changes is identical byte for byte.
+package dbc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+var magic = [4]byte{'W', 'D', 'B', 'C'}
+
+type Header struct {
+	Magic           [4]byte
+	RecordCount     uint32
+	FieldCount      uint32
+	RecordSize      uint32
+	StringBlockSize uint32
+}
+
+// File is a loaded DBC, records can be changed or appended and written back out
+type File struct {
+	Schema  *Schema
+	Records []*Record
+
+	strings []byte
+	offsets map[string]uint32
+}
+
+// Record is one row of a DBC file. Accessors panic when the column does not exist in the schema
+// or is a different type, a typo in a column name is a bug not a data problem.
+type Record struct {
+	file   *File
+	values []uint32
+}
+
+// New creates an empty DBC file for the schema
+func New(schema *Schema) *File {
+	return &File{
+		Schema:  schema,
+		strings: []byte{0},
+	}
+}
+
+// ReadHeader reads just the header of a DBC file
+func ReadHeader(r io.Reader) (Header, error) {
+	var header Header
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return Header{}, fmt.Errorf("failed to read DBC header: %v", err)
+	}
+
+	if header.Magic != magic {
+		return Header{}, fmt.Errorf("invalid DBC file: wrong magic identifier")
+	}
+
+	return header, nil
+}
+
+// Read loads a DBC file, the header must match the schema field count and record size
+func Read(r io.Reader, schema *Schema) (*File, error) {
+	header, err := ReadHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if int(header.FieldCount) != schema.FieldCount() || int(header.RecordSize) != schema.RecordSize() {
+		return nil, fmt.Errorf("%s schema has %d fields (%d bytes) but file has %d fields (%d bytes)",
+			schema.Name, schema.FieldCount(), schema.RecordSize(), header.FieldCount, header.RecordSize)
+	}
+
+	file := &File{
+		Schema:  schema,
+		Records: make([]*Record, 0, header.RecordCount),
+	}
+
+	data := make([]byte, int(header.RecordCount)*int(header.RecordSize))
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("failed to read %d records: %v", header.RecordCount, err)
+	}
+
+	for i := 0; i < int(header.RecordCount); i++ {
+		row := data[i*int(header.RecordSize) : (i+1)*int(header.RecordSize)]
+		values := make([]uint32, schema.FieldCount())
+		for j := range values {
+			values[j] = binary.LittleEndian.Uint32(row[j*4:])
+		}
+		file.Records = append(file.Records, &Record{file: file, values: values})
+	}
+
+	file.strings = make([]byte, header.StringBlockSize)
+	if _, err := io.ReadFull(r, file.strings); err != nil {
+		return nil, fmt.Errorf("failed to read string block: %v", err)
+	}
+
+	return file, nil
+}
+
+// ReadFile loads the DBC at path
+func ReadFile(path string, schema *Schema) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %v", err)
+	}
+	defer f.Close()
+
+	return Read(f, schema)
+}
+
+// Header builds the header for the current records and string block
+func (f *File) Header() Header {
+	return Header{
+		Magic:           magic,
+		RecordCount:     uint32(len(f.Records)),
+		FieldCount:      uint32(f.Schema.FieldCount()),
+		RecordSize:      uint32(f.Schema.RecordSize()),
+		StringBlockSize: uint32(len(f.strings)),
+	}
+}
+
+// Write writes the header, records and string block
+func (f *File) Write(w io.Writer) error {
+	buffer := new(bytes.Buffer)
+
+	if err := binary.Write(buffer, binary.LittleEndian, f.Header()); err != nil {
+		return fmt.Errorf("failed to write header: %v", err)
+	}
+
+	row := make([]byte, f.Schema.RecordSize())
+	for _, record := range f.Records {
+		for j, value := range record.values {
+			binary.LittleEndian.PutUint32(row[j*4:], value)
+		}
+		buffer.Write(row)
+	}
+
+	buffer.Write(f.strings)
+
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
+
+// WriteFile writes the DBC to path, replacing the file if it exists
+func (f *File) WriteFile(path string) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+
+	if err := f.Write(out); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// Add appends a zeroed record and returns it
+func (f *File) Add() *Record {
+	record := &Record{file: f, values: make([]uint32, f.Schema.FieldCount())}
+	f.Records = append(f.Records, record)
+	return record
+}
+
+// Find returns the first record with the given ID in the first column
+func (f *File) Find(id uint32) *Record {
+	for _, record := range f.Records {
+		if record.values[0] == id {
+			return record
+		}
+	}
+	return nil
+}
+
+// string at an offset in the string block
+func (f *File) stringAt(offset uint32) string {
+	if int(offset) >= len(f.strings) {
+		return ""
+	}
+
+	end := bytes.IndexByte(f.strings[offset:], 0)
+	if end < 0 {
+		return string(f.strings[offset:])
+	}
+	return string(f.strings[offset : int(offset)+end])
+}
+
+// offset of s in the string block, strings already in the block are reused and new ones are appended
+func (f *File) addString(s string) uint32 {
+	if f.offsets == nil {
+		f.offsets = map[string]uint32{}
+		start := 0
+		for i, b := range f.strings {
+			if b == 0 {
+				value := string(f.strings[start:i])
+				if _, exists := f.offsets[value]; !exists {
+					f.offsets[value] = uint32(start)
+				}
+				start = i + 1
+			}
+		}
+	}
+
+	if offset, exists := f.offsets[s]; exists {
+		return offset
+	}
+
+	// every block starts with the empty string, a new file might not have one yet
+	if len(f.strings) == 0 && s != "" {
+		f.strings = append(f.strings, 0)
+		f.offsets[""] = 0
+	}
+
+	offset := uint32(len(f.strings))
+	f.strings = append(f.strings, s...)
+	f.strings = append(f.strings, 0)
+	f.offsets[s] = offset
+
+	return offset
+}
+
+// ID is the first column, every DBC in 3.3.5 is keyed by it
+func (r *Record) ID() uint32 {
+	return r.values[0]
+}
+
+// Raw returns the 4 byte value of a column without interpreting it
+func (r *Record) Raw(column string) uint32 {
+	return r.values[r.file.Schema.mustIndex(column, Uint32, Int32, Float, String)]
+}
+
+func (r *Record) Uint32(column string) uint32 {
+	return r.values[r.file.Schema.mustIndex(column, Uint32)]
+}
+
+func (r *Record) Int32(column string) int32 {
+	return int32(r.values[r.file.Schema.mustIndex(column, Int32)])
+}
+
+func (r *Record) Float(column string) float32 {
+	return math.Float32frombits(r.values[r.file.Schema.mustIndex(column, Float)])
+}
+
+func (r *Record) String(column string) string {
+	return r.file.stringAt(r.values[r.file.Schema.mustIndex(column, String)])
+}
+
+func (r *Record) SetUint32(column string, value uint32) {
+	r.values[r.file.Schema.mustIndex(column, Uint32)] = value
+}
+
+func (r *Record) SetInt32(column string, value int32) {
+	r.values[r.file.Schema.mustIndex(column, Int32)] = uint32(value)
+}
+
+func (r *Record) SetFloat(column string, value float32) {
+	r.values[r.file.Schema.mustIndex(column, Float)] = math.Float32bits(value)
+}
+
+func (r *Record) SetString(column string, value string) {
+	i := r.file.Schema.mustIndex(column, String)
+	r.values[i] = r.file.addString(value)
+}
+
+// CopyFrom copies every column from another record of the same schema, strings are re-added
+// when the records belong to different files
+func (r *Record) CopyFrom(src *Record) {
+	for i, column := range r.file.Schema.Columns {
+		if column.Type == String && src.file != r.file {
+			r.values[i] = r.file.addString(src.file.stringAt(src.values[i]))
+			continue
+		}
+		r.values[i] = src.values[i]
+	}
+}
@@ -0,0 +1,278 @@
+package dbc
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		schema *Schema
+	}{
+		{"DurabilityCosts data", "../../data/dbc/DurabilityCosts.dbc", DurabilityCostsSchema},
+		{"DurabilityCosts tool", "../../cmd/durability-costs/DurabilityCosts.dbc", DurabilityCostsSchema},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original, err := os.ReadFile(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			file, err := Read(bytes.NewReader(original), tt.schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			out := new(bytes.Buffer)
+			if err := file.Write(out); err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(original, out.Bytes()) {
+				t.Errorf("round trip changed the file, %d bytes in %d bytes out", len(original), out.Len())
+			}
+		})
+	}
+}
+
+func TestReadSchemaMismatch(t *testing.T) {
+	original, err := os.ReadFile("../../data/dbc/DurabilityCosts.dbc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Read(bytes.NewReader(original), ItemSchema); err == nil {
+		t.Error("expected an error reading DurabilityCosts with the Item schema")
+	}
+}
+
+func TestDurabilityCostsValues(t *testing.T) {
+	file, err := ReadFile("../../data/dbc/DurabilityCosts.dbc", DurabilityCostsSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(file.Records) != 450 {
+		t.Fatalf("got %d records, want 450", len(file.Records))
+	}
+
+	record := file.Find(1)
+	if record == nil {
+		t.Fatal("record 1 not found")
+	}
+	if record.ID() != 1 || record.Uint32("ID") != 1 {
+		t.Errorf("record ID = %d", record.ID())
+	}
+}
+
+var testSchema = NewSchema("Test",
+	Field{Name: "ID", Type: Uint32},
+	Field{Name: "Points", Type: Int32, Count: 2},
+	Field{Name: "Speed", Type: Float},
+	Field{Name: "Icon", Type: String},
+	Field{Name: "Name", Type: LocString},
+)
+
+func TestSchemaColumns(t *testing.T) {
+	if testSchema.FieldCount() != 22 {
+		t.Errorf("FieldCount() = %d, want 22", testSchema.FieldCount())
+	}
+
+	for _, column := range []string{"Points_1", "Points_2", "Name_Lang_enUS", "Name_Lang_ruRU", "Name_Lang_Mask"} {
+		if _, ok := testSchema.Index(column); !ok {
+			t.Errorf("missing column %s", column)
+		}
+	}
+}
+
+func TestWriteAndReadStrings(t *testing.T) {
+	file := New(testSchema)
+
+	first := file.Add()
+	first.SetUint32("ID", 1)
+	first.SetInt32("Points_1", -5)
+	first.SetFloat("Speed", 1.5)
+	first.SetString("Icon", "Interface\\Icons\\Sword")
+	first.SetString("Name_Lang_enUS", "Fiery Blade")
+	first.SetString("Name_Lang_frFR", "Lame ardente")
+
+	second := file.Add()
+	second.SetUint32("ID", 2)
+	second.SetString("Icon", "Interface\\Icons\\Sword")
+	second.SetString("Name_Lang_enUS", "Fiery Blade")
+
+	out := new(bytes.Buffer)
+	if err := file.Write(out); err != nil {
+		t.Fatal(err)
+	}
+
+	// empty string plus three unique strings, the repeats are deduped
+	want := 1 + len("Interface\\Icons\\Sword\x00") + len("Fiery Blade\x00") + len("Lame ardente\x00")
+	if got := int(file.Header().StringBlockSize); got != want {
+		t.Errorf("StringBlockSize = %d, want %d", got, want)
+	}
+
+	read, err := Read(bytes.NewReader(out.Bytes()), testSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"Points_1", read.Find(1).Int32("Points_1"), int32(-5)},
+		{"Speed", read.Find(1).Float("Speed"), float32(1.5)},
+		{"Icon", read.Find(2).String("Icon"), "Interface\\Icons\\Sword"},
+		{"Name enUS", read.Find(2).String("Name_Lang_enUS"), "Fiery Blade"},
+		{"Name frFR", read.Find(1).String("Name_Lang_frFR"), "Lame ardente"},
+		{"Name deDE", read.Find(1).String("Name_Lang_deDE"), ""},
+		{"shared offset", read.Find(1).Raw("Icon"), read.Find(2).Raw("Icon")},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestWrongColumnTypePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic reading a string column as uint32")
+		}
+	}()
+
+	file := New(testSchema)
+	file.Add().Uint32("Icon")
+}
+
+func TestCompare(t *testing.T) {
+	orig, err := ReadFile("../../data/dbc/DurabilityCosts.dbc", DurabilityCostsSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	patched, err := ReadFile("../../data/dbc/DurabilityCosts.dbc", DurabilityCostsSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	patched.Find(5).SetUint32("ArmorSubClassCost_1", 99999)
+	patched.Records = patched.Records[1:]
+	for _, id := range []uint32{451, 452, 453, 460} {
+		patched.Add().SetUint32("ID", id)
+	}
+
+	diff, err := Compare(orig, patched)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"added", FormatIds(diff.Added), "451-453, 460"},
+		{"changed", FormatIds(diff.Changed), "5"},
+		{"removed", FormatIds(diff.Removed), "1"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.expected)
+		}
+	}
+}
+
+func TestOpenRawSchema(t *testing.T) {
+	file := New(ItemSchema)
+	file.Add().SetUint32("ID", 7)
+
+	path := t.TempDir() + "/Unknown.dbc"
+	if err := file.WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Schema.FieldCount() != ItemSchema.FieldCount() || read.Find(7) == nil {
+		t.Errorf("raw read got %d fields", read.Schema.FieldCount())
+	}
+}
+
+func TestPatchMerge(t *testing.T) {
+	dir := t.TempDir()
+	client := New(ItemSchema)
+	for _, id := range []uint32{1, 2} {
+		client.Add().SetUint32("ID", id)
+	}
+	clientPath := dir + "/Client.dbc"
+	if err := client.WriteFile(clientPath); err != nil {
+		t.Fatal(err)
+	}
+	out := dir + "/Item.dbc"
+
+	// generate, emblem, then generate again all writing to the same output
+	runs := []struct {
+		name     string
+		id       uint32
+		display  uint32
+		expected []uint32
+	}{
+		{"generate", 10, 100, []uint32{1, 2, 10}},
+		{"emblem", 20, 200, []uint32{1, 2, 10, 20}},
+		{"generate again", 10, 101, []uint32{1, 2, 10, 20}},
+	}
+	for _, run := range runs {
+		patch, err := OpenPatch(clientPath, ItemSchema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := patch.Merge(out); err != nil {
+			t.Fatalf("%s: %v", run.name, err)
+		}
+		record, err := patch.Create(run.id)
+		if err != nil {
+			t.Fatalf("%s: %v", run.name, err)
+		}
+		record.SetUint32("DisplayInfoID", run.display)
+		if patch.Count() != 1 {
+			t.Errorf("%s: count = %d, want 1", run.name, patch.Count())
+		}
+		if err := patch.WriteFile(out); err != nil {
+			t.Fatal(err)
+		}
+
+		written, err := ReadFile(out, ItemSchema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := []uint32{}
+		for _, record := range written.Records {
+			ids = append(ids, record.ID())
+		}
+		if FormatIds(ids) != FormatIds(run.expected) {
+			t.Errorf("%s: wrote %s, want %s", run.name, FormatIds(ids), FormatIds(run.expected))
+		}
+		if got := written.Find(run.id).Uint32("DisplayInfoID"); got != run.display {
+			t.Errorf("%s: display = %d, want %d", run.name, got, run.display)
+		}
+	}
+
+	patch, err := OpenPatch(out, ItemSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := patch.Merge(out); err == nil {
+		t.Error("expected merging into the file being patched to fail")
+	}
+}
@@ -0,0 +1,132 @@
+package dbc
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Schemas are the known layouts by file name without the .dbc extension
+var Schemas = map[string]*Schema{
+	"DurabilityCosts":  DurabilityCostsSchema,
+	"Item":             ItemSchema,
+	"ItemExtendedCost": ItemExtendedCostSchema,
+	"Spell":            SpellSchema,
+}
+
+// RawSchema is a layout of fieldCount uint32 columns for files without a known schema,
+// enough to compare records by id
+func RawSchema(name string, fieldCount int) *Schema {
+	if fieldCount <= 1 {
+		return NewSchema(name, Field{Name: "ID", Type: Uint32})
+	}
+	return NewSchema(name, Field{Name: "ID", Type: Uint32}, Field{Name: "Field", Type: Uint32, Count: fieldCount - 1})
+}
+
+// Open loads a DBC with the known schema for its file name, any other DBC is read with a RawSchema
+func Open(path string) (*File, error) {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if schema, ok := Schemas[name]; ok {
+		return ReadFile(path, schema)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %v", err)
+	}
+
+	header, err := ReadHeader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if header.FieldCount == 0 {
+		return nil, fmt.Errorf("%s has no fields", path)
+	}
+
+	return Read(bytes.NewReader(data), RawSchema(name, int(header.FieldCount)))
+}
+
+// Diff is the record ids that differ between two versions of a DBC
+type Diff struct {
+	Added   []uint32
+	Changed []uint32
+	Removed []uint32
+}
+
+// Compare the records of patched against orig by id, string columns are compared by value
+// so a string that only moved in the string block is not a change
+func Compare(orig, patched *File) (Diff, error) {
+	if orig.Schema.FieldCount() != patched.Schema.FieldCount() {
+		return Diff{}, fmt.Errorf("%s has %d fields but %s has %d", orig.Schema.Name, orig.Schema.FieldCount(),
+			patched.Schema.Name, patched.Schema.FieldCount())
+	}
+
+	before := map[uint32]*Record{}
+	for _, record := range orig.Records {
+		before[record.ID()] = record
+	}
+
+	diff := Diff{}
+	seen := map[uint32]bool{}
+	for _, record := range patched.Records {
+		id := record.ID()
+		seen[id] = true
+
+		old, exists := before[id]
+		if !exists {
+			diff.Added = append(diff.Added, id)
+			continue
+		}
+		if !sameRecord(old, record) {
+			diff.Changed = append(diff.Changed, id)
+		}
+	}
+
+	for id := range before {
+		if !seen[id] {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+
+	for _, ids := range [][]uint32{diff.Added, diff.Changed, diff.Removed} {
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	}
+
+	return diff, nil
+}
+
+func sameRecord(a, b *Record) bool {
+	for i, column := range a.file.Schema.Columns {
+		if column.Type == String {
+			if a.file.stringAt(a.values[i]) != b.file.stringAt(b.values[i]) {
+				return false
+			}
+			continue
+		}
+		if a.values[i] != b.values[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatIds prints ids as compact ranges e.g. 1-3, 7, 30000133
+func FormatIds(ids []uint32) string {
+	parts := []string{}
+	for i := 0; i < len(ids); {
+		j := i
+		for j+1 < len(ids) && ids[j+1] == ids[j]+1 {
+			j++
+		}
+		if j > i {
+			parts = append(parts, fmt.Sprintf("%d-%d", ids[i], ids[j]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d", ids[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,132 @@
+package dbc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Patch adds generated records on top of a client DBC. Records that were in the client file are never
+// changed, asking to create one of those ids is an error so a patch can't silently replace client data.
+type Patch struct {
+	File *File
+
+	path     string
+	existing map[uint32]*Record
+	created  map[uint32]*Record
+	merged   map[uint32]*Record
+}
+
+func NewPatch(file *File) *Patch {
+	existing := make(map[uint32]*Record, len(file.Records))
+	for _, record := range file.Records {
+		existing[record.ID()] = record
+	}
+
+	return &Patch{
+		File:     file,
+		existing: existing,
+		created:  map[uint32]*Record{},
+		merged:   map[uint32]*Record{},
+	}
+}
+
+// OpenPatch loads the client DBC at path to patch
+func OpenPatch(path string, schema *Schema) (*Patch, error) {
+	file, err := ReadFile(path, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s.dbc %s: %w", schema.Name, path, err)
+	}
+
+	patch := NewPatch(file)
+	patch.path = path
+	return patch, nil
+}
+
+// Merge keeps the generated records of an earlier patch written to path, so generate and emblem can
+// write to the same output and a rerun replaces its own records. Records of the client file are left
+// out, a missing file is nothing to merge. The output can't be the client file the patch was opened
+// from, its generated records would look like client data.
+func (p *Patch) Merge(path string) error {
+	out, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s.dbc %s: %w", p.File.Schema.Name, path, err)
+	}
+	if p.path != "" {
+		if in, err := os.Stat(p.path); err == nil && os.SameFile(in, out) {
+			return fmt.Errorf("%s is the client %s.dbc being patched, point it at the unmodified client file", path, p.File.Schema.Name)
+		}
+	}
+
+	file, err := ReadFile(path, p.File.Schema)
+	if err != nil {
+		return fmt.Errorf("failed to read %s.dbc %s: %w", p.File.Schema.Name, path, err)
+	}
+	for _, record := range file.Records {
+		id := record.ID()
+		if _, ok := p.existing[id]; ok {
+			continue
+		}
+		if _, ok := p.created[id]; ok {
+			continue
+		}
+		if _, ok := p.merged[id]; ok {
+			continue
+		}
+
+		merged := p.File.Add()
+		merged.CopyFrom(record)
+		p.merged[id] = merged
+	}
+
+	return nil
+}
+
+// Existing returns a record from the client file
+func (p *Patch) Existing(id uint32) (*Record, bool) {
+	record, ok := p.existing[id]
+	return record, ok
+}
+
+// Create returns the generated record for id, adding it to the file the first time. Creating the
+// same id again returns the same record so the caller can overwrite its values.
+func (p *Patch) Create(id uint32) (*Record, error) {
+	if record, ok := p.created[id]; ok {
+		return record, nil
+	}
+	if record, ok := p.merged[id]; ok {
+		delete(p.merged, id)
+		p.created[id] = record
+		return record, nil
+	}
+
+	if _, ok := p.existing[id]; ok {
+		return nil, fmt.Errorf("id %v already exists in the client %s.dbc", id, p.File.Schema.Name)
+	}
+
+	record := p.File.Add()
+	record.values[0] = id
+	p.created[id] = record
+
+	return record, nil
+}
+
+// Count is the number of records generated by this run, merged records are not counted
+func (p *Patch) Count() int {
+	return len(p.created)
+}
+
+// WriteFile writes the client records with the generated ones appended, creating the directory if needed
+func (p *Patch) WriteFile(path string) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+
+	return p.File.WriteFile(path)
+}
@@ -0,0 +1,106 @@
+package dbc
+
+import "fmt"
+
+type FieldType int
+
+const (
+	Uint32 FieldType = iota
+	Int32
+	Float
+	// String is a uint32 offset into the file string block
+	String
+	// LocString is the 3.3.5 localized string array, 16 string offsets followed by a uint32 flags mask
+	LocString
+)
+
+// Locales in the order they are stored in a LocString, the column names match the acore *_dbc tables
+var Locales = []string{
+	"enUS", "enGB", "koKR", "frFR", "deDE", "enCN", "zhCN", "enTW",
+	"zhTW", "esES", "esMX", "ruRU", "ptPT", "ptBR", "itIT", "Unk",
+}
+
+// Field declares one or more columns in a record. Count > 1 expands into Name_1..Name_N columns
+// the same way the acore *_dbc tables name array fields. A LocString expands into Name_Lang_<locale>
+// columns for every locale plus a Name_Lang_Mask column.
+type Field struct {
+	Name  string
+	Type  FieldType
+	Count int
+}
+
+// Column is a single 4 byte value in a record
+type Column struct {
+	Name string
+	Type FieldType
+}
+
+// Schema is the record layout of one DBC file
+type Schema struct {
+	Name    string
+	Columns []Column
+	index   map[string]int
+}
+
+// NewSchema expands the field declarations into columns. It panics on duplicate column names
+// since schemas are declared once in code.
+func NewSchema(name string, fields ...Field) *Schema {
+	schema := &Schema{Name: name, index: map[string]int{}}
+
+	for _, field := range fields {
+		switch {
+		case field.Type == LocString:
+			for _, locale := range Locales {
+				schema.add(field.Name+"_Lang_"+locale, String)
+			}
+			schema.add(field.Name+"_Lang_Mask", Uint32)
+		case field.Count > 1:
+			for i := 1; i <= field.Count; i++ {
+				schema.add(fmt.Sprintf("%s_%d", field.Name, i), field.Type)
+			}
+		default:
+			schema.add(field.Name, field.Type)
+		}
+	}
+
+	return schema
+}
+
+func (s *Schema) add(name string, fieldType FieldType) {
+	if _, exists := s.index[name]; exists {
+		panic(fmt.Sprintf("dbc: duplicate column %s in schema %s", name, s.Name))
+	}
+	s.index[name] = len(s.Columns)
+	s.Columns = append(s.Columns, Column{Name: name, Type: fieldType})
+}
+
+// FieldCount is the number of 4 byte columns in a record, this is what the header stores
+func (s *Schema) FieldCount() int {
+	return len(s.Columns)
+}
+
+// RecordSize is the size of a record in bytes
+func (s *Schema) RecordSize() int {
+	return len(s.Columns) * 4
+}
+
+// Index returns the position of a column in the record
+func (s *Schema) Index(name string) (int, bool) {
+	i, ok := s.index[name]
+	return i, ok
+}
+
+func (s *Schema) mustIndex(name string, types ...FieldType) int {
+	i, ok := s.index[name]
+	if !ok {
+		panic(fmt.Sprintf("dbc: %s has no column %s", s.Name, name))
+	}
+
+	for _, t := range types {
+		if s.Columns[i].Type == t {
+			return i
+		}
+	}
+
+	panic(fmt.Sprintf("dbc: column %s in %s is not the requested type", name, s.Name))
+}
@@ -0,0 +1,142 @@
+package dbc
+
+// Known 3.3.5 layouts, column names follow the matching acore_world *_dbc tables where there is one
+
+var DurabilityCostsSchema = NewSchema("DurabilityCosts",
+	Field{Name: "ID", Type: Uint32},
+	Field{Name: "WeaponSubClassCost", Type: Uint32, Count: 21},
+	Field{Name: "ArmorSubClassCost", Type: Uint32, Count: 8},
+)
+
+var ItemSchema = NewSchema("Item",
+	Field{Name: "ID", Type: Uint32},
+	Field{Name: "ClassID", Type: Uint32},
+	Field{Name: "SubclassID", Type: Uint32},
+	Field{Name: "Sound_Override_Subclassid", Type: Int32},
+	Field{Name: "Material", Type: Int32},
+	Field{Name: "DisplayInfoID", Type: Uint32},
+	Field{Name: "InventoryType", Type: Uint32},
+	Field{Name: "SheatheType", Type: Uint32},
+)
+
+var ItemExtendedCostSchema = NewSchema("ItemExtendedCost",
+	Field{Name: "ID", Type: Uint32},
+	Field{Name: "HonorPoints", Type: Uint32},
+	Field{Name: "ArenaPoints", Type: Uint32},
+	Field{Name: "ArenaBracket", Type: Uint32},
+	Field{Name: "ItemID", Type: Uint32, Count: 5},
+	Field{Name: "ItemCount", Type: Uint32, Count: 5},
+	Field{Name: "RequiredArenaRating", Type: Uint32},
+	Field{Name: "ItemPurchaseGroup", Type: Uint32},
+)
+
+// Spell.dbc, 234 columns matching acore_world.spell_dbc
+var SpellSchema = NewSchema("Spell",
+	Field{Name: "ID", Type: Uint32},
+	Field{Name: "Category", Type: Uint32},
+	Field{Name: "DispelType", Type: Uint32},
+	Field{Name: "Mechanic", Type: Uint32},
+	Field{Name: "Attributes", Type: Uint32},
+	Field{Name: "AttributesEx", Type: Uint32},
+	Field{Name: "AttributesEx2", Type: Uint32},
+	Field{Name: "AttributesEx3", Type: Uint32},
+	Field{Name: "AttributesEx4", Type: Uint32},
+	Field{Name: "AttributesEx5", Type: Uint32},
+	Field{Name: "AttributesEx6", Type: Uint32},
+	Field{Name: "AttributesEx7", Type: Uint32},
+	Field{Name: "ShapeshiftMask", Type: Uint32},
+	Field{Name: "unk_320_2", Type: Uint32},
+	Field{Name: "ShapeshiftExclude", Type: Uint32},
+	Field{Name: "unk_320_3", Type: Uint32},
+	Field{Name: "Targets", Type: Uint32},
+	Field{Name: "TargetCreatureType", Type: Uint32},
+	Field{Name: "RequiresSpellFocus", Type: Uint32},
+	Field{Name: "FacingCasterFlags", Type: Uint32},
+	Field{Name: "CasterAuraState", Type: Uint32},
+	Field{Name: "TargetAuraState", Type: Uint32},
+	Field{Name: "ExcludeCasterAuraState", Type: Uint32},
+	Field{Name: "ExcludeTargetAuraState", Type: Uint32},
+	Field{Name: "CasterAuraSpell", Type: Uint32},
+	Field{Name: "TargetAuraSpell", Type: Uint32},
+	Field{Name: "ExcludeCasterAuraSpell", Type: Uint32},
+	Field{Name: "ExcludeTargetAuraSpell", Type: Uint32},
+	Field{Name: "CastingTimeIndex", Type: Uint32},
+	Field{Name: "RecoveryTime", Type: Uint32},
+	Field{Name: "CategoryRecoveryTime", Type: Uint32},
+	Field{Name: "InterruptFlags", Type: Uint32},
+	Field{Name: "AuraInterruptFlags", Type: Uint32},
+	Field{Name: "ChannelInterruptFlags", Type: Uint32},
+	Field{Name: "ProcTypeMask", Type: Uint32},
+	Field{Name: "ProcChance", Type: Uint32},
+	Field{Name: "ProcCharges", Type: Uint32},
+	Field{Name: "MaxLevel", Type: Uint32},
+	Field{Name: "BaseLevel", Type: Uint32},
+	Field{Name: "SpellLevel", Type: Uint32},
+	Field{Name: "DurationIndex", Type: Uint32},
+	Field{Name: "PowerType", Type: Uint32},
+	Field{Name: "ManaCost", Type: Uint32},
+	Field{Name: "ManaCostPerLevel", Type: Uint32},
+	Field{Name: "ManaPerSecond", Type: Uint32},
+	Field{Name: "ManaPerSecondPerLevel", Type: Uint32},
+	Field{Name: "RangeIndex", Type: Uint32},
+	Field{Name: "Speed", Type: Float},
+	Field{Name: "ModalNextSpell", Type: Uint32},
+	Field{Name: "CumulativeAura", Type: Uint32},
+	Field{Name: "Totem", Type: Uint32, Count: 2},
+	Field{Name: "Reagent", Type: Int32, Count: 8},
+	Field{Name: "ReagentCount", Type: Uint32, Count: 8},
+	Field{Name: "EquippedItemClass", Type: Int32},
+	Field{Name: "EquippedItemSubclass", Type: Int32},
+	Field{Name: "EquippedItemInvTypes", Type: Int32},
+	Field{Name: "Effect", Type: Uint32, Count: 3},
+	Field{Name: "EffectDieSides", Type: Int32, Count: 3},
+	Field{Name: "EffectRealPointsPerLevel", Type: Float, Count: 3},
+	Field{Name: "EffectBasePoints", Type: Int32, Count: 3},
+	Field{Name: "EffectMechanic", Type: Uint32, Count: 3},
+	Field{Name: "ImplicitTargetA", Type: Uint32, Count: 3},
+	Field{Name: "ImplicitTargetB", Type: Uint32, Count: 3},
+	Field{Name: "EffectRadiusIndex", Type: Uint32, Count: 3},
+	Field{Name: "EffectAura", Type: Uint32, Count: 3},
+	Field{Name: "EffectAuraPeriod", Type: Uint32, Count: 3},
+	Field{Name: "EffectMultipleValue", Type: Float, Count: 3},
+	Field{Name: "EffectChainTargets", Type: Uint32, Count: 3},
+	Field{Name: "EffectItemType", Type: Uint32, Count: 3},
+	Field{Name: "EffectMiscValue", Type: Int32, Count: 3},
+	Field{Name: "EffectMiscValueB", Type: Int32, Count: 3},
+	Field{Name: "EffectTriggerSpell", Type: Uint32, Count: 3},
+	Field{Name: "EffectPointsPerCombo", Type: Float, Count: 3},
+	Field{Name: "EffectSpellClassMaskA", Type: Uint32, Count: 3},
+	Field{Name: "EffectSpellClassMaskB", Type: Uint32, Count: 3},
+	Field{Name: "EffectSpellClassMaskC", Type: Uint32, Count: 3},
+	Field{Name: "SpellVisualID", Type: Uint32, Count: 2},
+	Field{Name: "SpellIconID", Type: Uint32},
+	Field{Name: "ActiveIconID", Type: Uint32},
+	Field{Name: "SpellPriority", Type: Uint32},
+	Field{Name: "Name", Type: LocString},
+	Field{Name: "NameSubtext", Type: LocString},
+	Field{Name: "Description", Type: LocString},
+	Field{Name: "AuraDescription", Type: LocString},
+	Field{Name: "ManaCostPct", Type: Uint32},
+	Field{Name: "StartRecoveryCategory", Type: Uint32},
+	Field{Name: "StartRecoveryTime", Type: Uint32},
+	Field{Name: "MaxTargetLevel", Type: Uint32},
+	Field{Name: "SpellClassSet", Type: Uint32},
+	Field{Name: "SpellClassMask", Type: Uint32, Count: 3},
+	Field{Name: "MaxTargets", Type: Uint32},
+	Field{Name: "DefenseType", Type: Uint32},
+	Field{Name: "PreventionType", Type: Uint32},
+	Field{Name: "StanceBarOrder", Type: Uint32},
+	Field{Name: "EffectChainAmplitude", Type: Float, Count: 3},
+	Field{Name: "MinFactionID", Type: Uint32},
+	Field{Name: "MinReputation", Type: Uint32},
+	Field{Name: "RequiredAuraVision", Type: Uint32},
+	Field{Name: "RequiredTotemCategoryID", Type: Uint32, Count: 2},
+	Field{Name: "RequiredAreasID", Type: Uint32},
+	Field{Name: "SchoolMask", Type: Uint32},
+	Field{Name: "RuneCostID", Type: Uint32},
+	Field{Name: "SpellMissileID", Type: Uint32},
+	Field{Name: "PowerDisplayID", Type: Uint32},
+	Field{Name: "EffectBonusMultiplier", Type: Float, Count: 3},
+	Field{Name: "SpellDescriptionVariableID", Type: Uint32},
+	Field{Name: "SpellDifficultyID", Type: Uint32},
+)
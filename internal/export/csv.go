@@ -0,0 +1,58 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/ids"
+	"github.com/gocarina/gocsv"
+)
+
+// CsvRow is the record in the DbItemCsv layout the emblem command reads. The entry is the emblem
+// vendor entry of the source item so the importer finds the source row when the file is fed back.
+func CsvRow(record Record) *mysql.DbItemCsv {
+	item := record.Item
+	row := &mysql.DbItemCsv{
+		Entry:         ids.VendorItems.ID(record.SourceEntry),
+		Name:          record.Name,
+		DisplayId:     item.DisplayId,
+		Quality:       item.Quality,
+		ItemLevel:     item.ItemLevel,
+		Class:         item.Class,
+		Subclass:      item.Subclass,
+		InventoryType: item.InventoryType,
+		RequiredLevel: item.RequiredLevel,
+		StatsCount:    item.StatsCount,
+	}
+
+	// copy the stat slots over by name, both structs use StatTypeN and StatValueN
+	stats := []struct{ from, to **int }{
+		{&item.StatType1, &row.StatType1}, {&item.StatValue1, &row.StatValue1},
+		{&item.StatType2, &row.StatType2}, {&item.StatValue2, &row.StatValue2},
+		{&item.StatType3, &row.StatType3}, {&item.StatValue3, &row.StatValue3},
+		{&item.StatType4, &row.StatType4}, {&item.StatValue4, &row.StatValue4},
+		{&item.StatType5, &row.StatType5}, {&item.StatValue5, &row.StatValue5},
+		{&item.StatType6, &row.StatType6}, {&item.StatValue6, &row.StatValue6},
+		{&item.StatType7, &row.StatType7}, {&item.StatValue7, &row.StatValue7},
+		{&item.StatType8, &row.StatType8}, {&item.StatValue8, &row.StatValue8},
+		{&item.StatType9, &row.StatType9}, {&item.StatValue9, &row.StatValue9},
+		{&item.StatType10, &row.StatType10}, {&item.StatValue10, &row.StatValue10},
+	}
+	for _, stat := range stats {
+		*stat.to = *stat.from
+	}
+	return row
+}
+
+// WriteCsv writes the items in the DbItemCsv layout, one row per item
+func (d *Document) WriteCsv(w io.Writer) error {
+	rows := make([]*mysql.DbItemCsv, len(d.Items))
+	for i, record := range d.Items {
+		rows[i] = CsvRow(record)
+	}
+	if err := gocsv.Marshal(rows, w); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/config"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/items"
+	"github.com/araxiaonline/endgame-item-generator/internal/rng"
+)
+
+// Reference is the item a generated item took its stat spread from
+type Reference struct {
+	Source    string `json:"source"` // the lookup that found it, GetRandItem or GetByNameAndDifficulty
+	Entry     int    `json:"entry"`
+	Name      string `json:"name"`
+	ItemLevel int    `json:"itemLevel"`
+}
+
+func NewReference(source string, item mysql.DbItem) *Reference {
+	ref := &Reference{Source: source, Entry: item.Entry, Name: item.Name}
+	if item.ItemLevel != nil {
+		ref.ItemLevel = *item.ItemLevel
+	}
+	return ref
+}
+
+type Stat struct {
+	Id       int     `json:"id"`
+	Type     string  `json:"type,omitempty"`
+	Value    int     `json:"value"`
+	Percent  float64 `json:"percent"`
+	AdjValue float64 `json:"adjValue,omitempty"`
+}
+
+type Spell struct {
+	SourceId   int    `json:"sourceId"`
+	Id         int    `json:"id"`
+	Name       string `json:"name"`
+	BasePoints [3]int `json:"basePoints"`
+}
+
+// Record is one generated item with everything that went into it
+type Record struct {
+	Entry         int          `json:"entry"`
+	SourceEntry   int          `json:"sourceEntry"`
+	Name          string       `json:"name"`
+	Difficulty    int          `json:"difficulty"`
+	Tier          int          `json:"tier,omitempty"`
+	RequiredLevel int          `json:"requiredLevel"`
+	SellPrice     int          `json:"sellPrice,omitempty"`
+	Reference     *Reference   `json:"reference,omitempty"`
+	Stats         []Stat       `json:"stats"`
+	Spells        []Spell      `json:"spells"`
+	Item          mysql.DbItem `json:"item"`
+}
+
+// NewRecord is the record for an item written with the ItemStatements of the same arguments. The name word
+// and sell price are picked the same way so a seeded run gives the same values as the sql would have.
+func NewRecord(item items.Item, reqLevel, difficulty int, ref *Reference) Record {
+	name := items.GeneratedName(item, difficulty)
+	return newRecord(item, items.GeneratedEntry(item.Entry, difficulty), name, reqLevel, difficulty, items.SellPrice(), ref)
+}
+
+// NewWrittenRecord is the record for an item already written under entry with its final name, like the emblem vendor items
+func NewWrittenRecord(item items.Item, entry, sourceEntry, tier int, ref *Reference) Record {
+	reqLevel := 0
+	if item.RequiredLevel != nil {
+		reqLevel = *item.RequiredLevel
+	}
+	record := newRecord(item, entry, item.Name, reqLevel, item.Difficulty, 0, ref)
+	record.SourceEntry = sourceEntry
+	record.Tier = tier
+	return record
+}
+
+func newRecord(item items.Item, entry int, name string, reqLevel, difficulty, sellPrice int, ref *Reference) Record {
+	quality := 0
+	if item.Quality != nil {
+		quality = *item.Quality
+	}
+
+	// a copy so the record does not change with the item it came from, pointing at the scaled spells
+	written := items.ItemFromDbItem(item.DbItem)
+	written.Spells = item.Spells
+	if item.Quality != nil {
+		written.UseSpellWriteIds()
+	}
+	final := written.DbItem
+	final.Entry = entry
+	final.Name = name
+	final.RequiredLevel = &reqLevel
+
+	record := Record{
+		Entry:         entry,
+		SourceEntry:   item.Entry,
+		Name:          name,
+		Difficulty:    difficulty,
+		RequiredLevel: reqLevel,
+		SellPrice:     sellPrice,
+		Reference:     ref,
+		Stats:         stats(item),
+		Spells:        []Spell{},
+		Item:          final,
+	}
+	for _, spell := range item.Spells {
+		record.Spells = append(record.Spells, Spell{
+			SourceId:   spell.ID,
+			Id:         spell.WriteId(quality),
+			Name:       spell.Name,
+			BasePoints: [3]int{spell.EffectBasePoints1, spell.EffectBasePoints2, spell.EffectBasePoints3},
+		})
+	}
+	return record
+}
+
+// the scaled stats with their share of the budget, items that were not scaled here only have their stat slots
+func stats(item items.Item) []Stat {
+	list := []Stat{}
+	if item.StatsMap != nil {
+		for id, stat := range item.StatsMap {
+			list = append(list, Stat{Id: id, Type: stat.Type, Value: stat.Value, Percent: stat.Percent, AdjValue: stat.AdjValue})
+		}
+	} else {
+		for i := 1; i <= 10; i++ {
+			statType, err := item.GetField(fmt.Sprintf("StatType%d", i))
+			if err != nil || statType == 0 {
+				continue
+			}
+			value, _ := item.GetField(fmt.Sprintf("StatValue%d", i))
+			list = append(list, Stat{Id: statType, Value: value})
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Id < list[j].Id })
+	return list
+}
+
+// Document is a whole run, the settings it was generated with and every item
+type Document struct {
+	Tool    string   `json:"tool"`
+	Seed    uint64   `json:"seed"`
+	Profile string   `json:"profile"`
+	Version int      `json:"profileVersion"`
+	Scaler  string   `json:"scaler"`
+	Items   []Record `json:"items"`
+}
+
+// NewDocument starts a document for tool with the seed, profile and scaler currently in use
+func NewDocument(tool, scaler string) *Document {
+	profile := config.CurrentProfile()
+	return &Document{
+		Tool:    tool,
+		Seed:    rng.CurrentSeed(),
+		Profile: profile.Name,
+		Version: profile.Version,
+		Scaler:  scaler,
+		Items:   []Record{},
+	}
+}
+
+func (d *Document) Add(record Record) {
+	d.Items = append(d.Items, record)
+}
+
+func (d *Document) WriteJson(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(d)
+}
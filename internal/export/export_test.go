@@ -0,0 +1,112 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/ids"
+	"github.com/araxiaonline/endgame-item-generator/internal/items"
+	"github.com/araxiaonline/endgame-item-generator/internal/rng"
+	"github.com/araxiaonline/endgame-item-generator/internal/spells"
+	"github.com/gocarina/gocsv"
+)
+
+func testItem() items.Item {
+	quality, level, stat := 4, 300, 7
+	item := items.ItemFromDbItem(mysql.DbItem{Entry: 100, Name: "Sword", Quality: &quality, ItemLevel: &level, StatType1: &stat})
+	item.StatsMap = map[int]*items.ItemStat{
+		7: {Value: 120, Percent: 0.6, Type: "Stamina"},
+		4: {Value: 80, Percent: 0.4, Type: "Strength"},
+	}
+	item.Spells = []spells.Spell{{DbSpell: mysql.DbSpell{ID: 21919, Name: "Chilled", EffectBasePoints1: 250}, ItemSpellSlot: 1}}
+	return item
+}
+
+func TestNewRecord(t *testing.T) {
+	ref := NewReference("GetRandItem", mysql.DbItem{Entry: 40000, Name: "Titan Sword"})
+	record := NewRecord(testItem(), 80, 4, ref)
+
+	if record.Entry != 21000100 || record.SourceEntry != 100 || record.Item.Entry != 21000100 {
+		t.Errorf("expected entry 21000100 from 100, got %d from %d (item %d)", record.Entry, record.SourceEntry, record.Item.Entry)
+	}
+	if record.Name != record.Item.Name || record.Name == "Sword" {
+		t.Errorf("expected the generated name on the record and item, got %q and %q", record.Name, record.Item.Name)
+	}
+	if *record.Item.RequiredLevel != 80 {
+		t.Errorf("expected required level 80, got %d", *record.Item.RequiredLevel)
+	}
+	if len(record.Stats) != 2 || record.Stats[0].Id != 4 || record.Stats[1].Percent != 0.6 {
+		t.Errorf("expected stats in id order with percents, got %+v", record.Stats)
+	}
+	if len(record.Spells) != 1 || record.Spells[0].Id != 31021919 || record.Spells[0].BasePoints[0] != 250 {
+		t.Errorf("unexpected spells %+v", record.Spells)
+	}
+	if record.Item.SpellId1 == nil || *record.Item.SpellId1 != record.Spells[0].Id {
+		t.Errorf("expected the item to point at scaled spell %d, got %v", record.Spells[0].Id, record.Item.SpellId1)
+	}
+	if record.Reference.Entry != 40000 || record.Reference.Source != "GetRandItem" {
+		t.Errorf("unexpected reference %+v", record.Reference)
+	}
+}
+
+func TestDocumentIsRepeatable(t *testing.T) {
+	write := func() []byte {
+		rng.Seed(7)
+		doc := NewDocument("item-gen", "v3")
+		doc.Add(NewRecord(testItem(), 80, 3, nil))
+		out := new(bytes.Buffer)
+		if err := doc.WriteJson(out); err != nil {
+			t.Fatal(err)
+		}
+		return out.Bytes()
+	}
+
+	first, second := write(), write()
+	if !bytes.Equal(first, second) {
+		t.Errorf("same seed gave different json:\n%s\n%s", first, second)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(first, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["seed"] != float64(7) || len(decoded["items"].([]interface{})) != 1 {
+		t.Errorf("unexpected document %s", first)
+	}
+}
+
+func TestCsvRoundTrip(t *testing.T) {
+	doc := NewDocument("item-gen", "v3")
+	item := testItem()
+	value, count := 120, 1
+	item.StatValue1 = &value
+	item.StatsCount = &count
+	doc.Add(NewRecord(item, 80, 4, nil))
+
+	out := new(bytes.Buffer)
+	if err := doc.WriteCsv(out); err != nil {
+		t.Fatal(err)
+	}
+
+	// read back the way the emblem command reads its spreadsheets
+	rows := []*mysql.DbItemCsv{}
+	if err := gocsv.UnmarshalBytes(out.Bytes(), &rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d:\n%s", len(rows), out)
+	}
+	row := rows[0]
+	if ids.VendorItems.Source(row.Entry) != 100 {
+		t.Errorf("expected the importer to find source 100, entry is %d", row.Entry)
+	}
+	if row.Name != doc.Items[0].Name || *row.Quality != 4 || *row.ItemLevel != 300 || *row.RequiredLevel != 80 {
+		t.Errorf("unexpected row %+v", row)
+	}
+	if *row.StatsCount != 1 || *row.StatType1 != 7 || *row.StatValue1 != 120 || *row.StatType2 != 0 {
+		// empty slots are written as 0 so the importer clears what the source item had there
+		t.Errorf("unexpected stats %v %v %v %v", *row.StatsCount, *row.StatType1, *row.StatValue1, *row.StatType2)
+	}
+}
@@ -0,0 +1,387 @@
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kind is the type of a field's values
+type Kind int
+
+const (
+	Int Kind = iota
+	String
+)
+
+// Field is a name an expression can compare. A field with a table and column can be handed to the
+// database, the others are only known once the tool has read the item.
+type Field struct {
+	Kind   Kind
+	Table  string
+	Column string
+	Words  []string // the values a String field can have, empty for any
+}
+
+type Fields map[string]Field
+
+// Values are the fields of one item, ints for Int fields and strings for String fields
+type Values map[string]interface{}
+
+// Filter is a parsed -where expression like `map=289 and class=4 and subclass in (3,4)`. Comparisons
+// are =, !=, <, <=, >, >= and in (...), joined with and, or, not and parentheses. Strings compare
+// without case and can be quoted. A nil Filter matches everything.
+type Filter struct {
+	text   string
+	root   node
+	fields Fields
+}
+
+func Parse(text string, fields Fields) (*Filter, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, fields: fields}
+	root, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q at %d", p.tokens[p.pos].text, p.tokens[p.pos].pos)
+	}
+	return &Filter{text: text, root: root, fields: fields}, nil
+}
+
+func (f *Filter) String() string {
+	if f == nil {
+		return ""
+	}
+	return f.text
+}
+
+func (f *Filter) Match(values Values) bool {
+	if f == nil {
+		return true
+	}
+	return f.root.match(values)
+}
+
+// Clause is a WHERE fragment with ? placeholders for the parts of the filter a table can answer on
+// its own, and together with the rest of the query. Only the top level and-ed comparisons are taken.
+// absent are the values of an item that has no row in the table, comparisons such an item passes are
+// left to Match so it is not dropped by the query. An empty clause is no condition.
+func (f *Filter) Clause(table string, absent Values) (string, []interface{}) {
+	if f == nil {
+		return "", nil
+	}
+
+	parts := []string{}
+	args := []interface{}{}
+	for _, n := range conjuncts(f.root) {
+		if !n.inTable(table, f.fields) || (absent != nil && n.match(absent)) {
+			continue
+		}
+		sql, nodeArgs := n.sql(f.fields)
+		parts = append(parts, sql)
+		args = append(args, nodeArgs...)
+	}
+	return strings.Join(parts, " AND "), args
+}
+
+// Names are the field names sorted for help text
+func (fields Fields) Names() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type node interface {
+	match(values Values) bool
+	inTable(table string, fields Fields) bool
+	sql(fields Fields) (string, []interface{})
+}
+
+func conjuncts(n node) []node {
+	if and, ok := n.(*binary); ok && and.and {
+		return append(conjuncts(and.left), conjuncts(and.right)...)
+	}
+	return []node{n}
+}
+
+type binary struct {
+	and         bool
+	left, right node
+}
+
+func (b *binary) match(values Values) bool {
+	if b.and {
+		return b.left.match(values) && b.right.match(values)
+	}
+	return b.left.match(values) || b.right.match(values)
+}
+
+func (b *binary) inTable(table string, fields Fields) bool {
+	return b.left.inTable(table, fields) && b.right.inTable(table, fields)
+}
+
+func (b *binary) sql(fields Fields) (string, []interface{}) {
+	left, leftArgs := b.left.sql(fields)
+	right, rightArgs := b.right.sql(fields)
+	op := "OR"
+	if b.and {
+		op = "AND"
+	}
+	return fmt.Sprintf("(%s %s %s)", left, op, right), append(leftArgs, rightArgs...)
+}
+
+type not struct{ n node }
+
+func (n *not) match(values Values) bool { return !n.n.match(values) }
+
+func (n *not) inTable(table string, fields Fields) bool { return n.n.inTable(table, fields) }
+
+func (n *not) sql(fields Fields) (string, []interface{}) {
+	sql, args := n.n.sql(fields)
+	return "NOT " + sql, args
+}
+
+type comparison struct {
+	field  string
+	op     string // =, !=, <, <=, >, >= or in
+	values []interface{}
+}
+
+func (c *comparison) match(values Values) bool {
+	value, ok := values[c.field]
+	if !ok {
+		return false
+	}
+	switch c.op {
+	case "=", "in":
+		for _, v := range c.values {
+			if compare(value, v) == 0 {
+				return true
+			}
+		}
+		return false
+	case "!=":
+		return compare(value, c.values[0]) != 0
+	case "<":
+		return compare(value, c.values[0]) < 0
+	case "<=":
+		return compare(value, c.values[0]) <= 0
+	case ">":
+		return compare(value, c.values[0]) > 0
+	case ">=":
+		return compare(value, c.values[0]) >= 0
+	}
+	return false
+}
+
+func (c *comparison) inTable(table string, fields Fields) bool {
+	field := fields[c.field]
+	return field.Table == table && field.Column != ""
+}
+
+func (c *comparison) sql(fields Fields) (string, []interface{}) {
+	column := fields[c.field].Column
+	if c.op == "in" {
+		marks := strings.TrimSuffix(strings.Repeat("?,", len(c.values)), ",")
+		return fmt.Sprintf("%s IN (%s)", column, marks), c.values
+	}
+	return fmt.Sprintf("%s %s ?", column, c.op), c.values
+}
+
+// compare orders two ints or two strings, strings without case
+func compare(a, b interface{}) int {
+	switch a := a.(type) {
+	case int:
+		b, _ := b.(int)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case string:
+		b, _ := b.(string)
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+	return -1
+}
+
+var operators = map[string]bool{"=": true, "==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true}
+
+type parser struct {
+	tokens []token
+	pos    int
+	fields Fields
+}
+
+func (p *parser) peek() token {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return token{kind: end}
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if t.kind != end {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) keyword(word string) bool {
+	if t := p.peek(); t.kind == ident && strings.EqualFold(t.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &binary{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binary{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (node, error) {
+	if p.keyword("not") {
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &not{n}, nil
+	}
+
+	if p.peek().text == "(" && p.peek().kind == symbol {
+		p.next()
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.text != ")" {
+			return nil, unexpected(t, "a closing )")
+		}
+		return n, nil
+	}
+	return p.comparison()
+}
+
+func (p *parser) comparison() (node, error) {
+	t := p.next()
+	if t.kind != ident {
+		return nil, unexpected(t, "a field name")
+	}
+	name := strings.ToLower(t.text)
+	field, ok := p.fields[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q at %d, use one of %s", t.text, t.pos, strings.Join(p.fields.Names(), ", "))
+	}
+
+	c := &comparison{field: name}
+	if p.keyword("in") {
+		if t := p.next(); t.text != "(" {
+			return nil, unexpected(t, "( after in")
+		}
+		for {
+			value, err := p.value(name, field)
+			if err != nil {
+				return nil, err
+			}
+			c.values = append(c.values, value)
+			if t := p.next(); t.text == ")" {
+				break
+			} else if t.text != "," {
+				return nil, unexpected(t, ", or )")
+			}
+		}
+		c.op = "in"
+		return c, nil
+	}
+
+	op := p.next()
+	if op.kind != symbol || !operators[op.text] {
+		return nil, unexpected(op, "a comparison after "+name)
+	}
+	c.op = op.text
+	if c.op == "==" {
+		c.op = "="
+	}
+	if field.Kind == String && c.op != "=" && c.op != "!=" {
+		return nil, fmt.Errorf("%s can only be compared with = or != at %d", name, op.pos)
+	}
+	value, err := p.value(name, field)
+	if err != nil {
+		return nil, err
+	}
+	c.values = []interface{}{value}
+	return c, nil
+}
+
+func (p *parser) value(name string, field Field) (interface{}, error) {
+	t := p.next()
+	if field.Kind == Int {
+		n, err := strconv.Atoi(t.text)
+		if t.kind != number || err != nil {
+			return nil, unexpected(t, "a number for "+name)
+		}
+		return n, nil
+	}
+
+	if t.kind != ident && t.kind != quoted && t.kind != number {
+		return nil, unexpected(t, "a value for "+name)
+	}
+	if len(field.Words) > 0 {
+		for _, word := range field.Words {
+			if strings.EqualFold(word, t.text) {
+				return word, nil
+			}
+		}
+		return nil, fmt.Errorf("%s can not be %q at %d, use one of %s", name, t.text, t.pos, strings.Join(field.Words, ", "))
+	}
+	return t.text, nil
+}
+
+func unexpected(t token, expected string) error {
+	if t.kind == end {
+		return fmt.Errorf("expected %s at the end", expected)
+	}
+	return fmt.Errorf("expected %s at %d, got %q", expected, t.pos, t.text)
+}
@@ -0,0 +1,110 @@
+package filter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var testFields = Fields{
+	"entry":    {Kind: Int, Table: "item_template", Column: "entry"},
+	"class":    {Kind: Int, Table: "item_template", Column: "class"},
+	"subclass": {Kind: Int, Table: "item_template", Column: "subclass"},
+	"map":      {Kind: Int, Table: "dungeon_items", Column: "mapId"},
+	"boss":     {Kind: Int, Table: "dungeon_items", Column: "creatureId"},
+	"name":     {Kind: String},
+	"role":     {Kind: String, Words: []string{"tank", "healer", "caster"}},
+}
+
+func TestMatch(t *testing.T) {
+	plate := Values{"entry": 100, "class": 4, "subclass": 4, "map": 289, "boss": 0, "name": "Darkmantle Helm", "role": "tank"}
+
+	tests := []struct {
+		where string
+		match bool
+	}{
+		{"", true},
+		{"map=289 and class=4 and subclass=4", true},
+		{"map=289 and subclass=3", false},
+		{"subclass in (3, 4)", true},
+		{"subclass in (1,2,3)", false},
+		{"class = 2 or map == 289", true},
+		{"not boss = 0", false},
+		{"boss != 0 or (class >= 4 and entry < 200)", true},
+		{"ENTRY > 100", false},
+		{"entry <= 100 and entry>99", true},
+		{`name = "darkmantle helm"`, true},
+		{"name != 'Darkmantle Helm'", false},
+		{"role=TANK", true},
+		{"role in (healer, caster)", false},
+		{"class=4 and not (role=tank or role=healer)", false},
+	}
+
+	for _, tt := range tests {
+		f, err := Parse(tt.where, testFields)
+		if err != nil {
+			t.Errorf("%q: %v", tt.where, err)
+			continue
+		}
+		if got := f.Match(plate); got != tt.match {
+			t.Errorf("%q: match = %v, want %v", tt.where, got, tt.match)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		where    string
+		expected string
+	}{
+		{"level=60", `unknown field "level" at 0`},
+		{"class=plate", "expected a number for class at 6"},
+		{"role=dps", `role can not be "dps"`},
+		{"name < b", "name can only be compared with = or !="},
+		{"class=4 and", "expected a field name at the end"},
+		{"(class=4", "expected a closing ) at the end"},
+		{"subclass in (1 2)", `expected , or ) at 15, got "2"`},
+		{"class=4 class=2", `unexpected "class" at 8`},
+		{"class ! 4", "expected != at 6"},
+		{`name="helm`, "unclosed quote at 5"},
+	}
+
+	for _, tt := range tests {
+		_, err := Parse(tt.where, testFields)
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%q: error = %v, want %q", tt.where, err, tt.expected)
+		}
+	}
+}
+
+func TestClause(t *testing.T) {
+	absent := Values{"map": 0, "boss": 0}
+
+	tests := []struct {
+		where  string
+		table  string
+		absent Values
+		clause string
+		args   []interface{}
+	}{
+		{"map=289 and class=4 and subclass in (3,4)", "item_template", nil, "class = ? AND subclass IN (?,?)", []interface{}{4, 3, 4}},
+		{"map=289 and class=4 and subclass in (3,4)", "dungeon_items", absent, "mapId = ?", []interface{}{289}},
+		{"(class=2 or class=4) and not subclass=20", "item_template", nil, "(class = ? OR class = ?) AND NOT subclass = ?", []interface{}{2, 4, 20}},
+		// the or needs the role, so only the tool can decide it
+		{"class=4 or role=tank", "item_template", nil, "", []interface{}{}},
+		// items that are not from a dungeon pass these, so they stay in Match
+		{"map != 289 and boss = 0", "dungeon_items", absent, "", []interface{}{}},
+		{"boss != 0", "dungeon_items", absent, "creatureId != ?", []interface{}{0}},
+	}
+
+	for _, tt := range tests {
+		f, err := Parse(tt.where, testFields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		clause, args := f.Clause(tt.table, tt.absent)
+		if clause != tt.clause || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("%q on %s: got %q %v, want %q %v", tt.where, tt.table, clause, args, tt.clause, tt.args)
+		}
+	}
+}
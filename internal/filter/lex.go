@@ -0,0 +1,80 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	end tokenKind = iota
+	ident
+	number
+	quoted
+	symbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // character offset in the expression, for errors
+}
+
+func tokenize(text string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			close := i + 1
+			for close < len(runes) && runes[close] != r {
+				close++
+			}
+			if close == len(runes) {
+				return nil, fmt.Errorf("unclosed quote at %d", i)
+			}
+			tokens = append(tokens, token{quoted, string(runes[i+1 : close]), i})
+			i = close + 1
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			i++
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{number, string(runes[start:i]), start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '-') {
+				i++
+			}
+			tokens = append(tokens, token{ident, string(runes[start:i]), start})
+		case strings.ContainsRune("()=,", r):
+			if r == '=' && i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, token{symbol, "==", i})
+				i += 2
+				continue
+			}
+			tokens = append(tokens, token{symbol, string(r), i})
+			i++
+		case r == '!' || r == '<' || r == '>':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, token{symbol, string(runes[i : i+2]), i})
+				i += 2
+				continue
+			}
+			if r == '!' {
+				return nil, fmt.Errorf("expected != at %d", i)
+			}
+			tokens = append(tokens, token{symbol, string(r), i})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected %q at %d", r, i)
+		}
+	}
+	return tokens, nil
+}
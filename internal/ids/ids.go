@@ -0,0 +1,270 @@
+package ids
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlrow"
+)
+
+// Kind is the id space a range lives in, item entries and spell ids never collide with each other
+type Kind string
+
+const (
+	Items  Kind = "item"
+	Spells Kind = "spell"
+)
+
+// the table and key source rows of a kind are read from
+var sources = map[Kind]struct{ table, key string }{
+	Items:  {"item_template", "entry"},
+	Spells: {"spell_dbc", "ID"},
+}
+
+// Range is a block of ids one product line writes generated rows to, a row generated from source id n
+// is written under Base + n so n has to be below Size
+type Range struct {
+	Name  string
+	Kind  Kind
+	Base  int
+	Size  int
+	Table string // where the generated rows are written
+
+	// column the writer copies unchanged from the source row, a row already sitting on the id with
+	// a different value came from something else. Empty when the writer overwrites everything.
+	Match string
+}
+
+// ID of the row generated from source, see Allocate for the checked version
+func (r Range) ID(source int) int {
+	return r.Base + source
+}
+
+// Source is the id the row id was generated from
+func (r Range) Source(id int) int {
+	return id - r.Base
+}
+
+func (r Range) Contains(id int) bool {
+	return id >= r.Base && id < r.Base+r.Size
+}
+
+func (r Range) Overlaps(o Range) bool {
+	return r.Kind == o.Kind && r.Base < o.Base+o.Size && o.Base < r.Base+r.Size
+}
+
+// Allocate is ID but fails when the source id is too big for the range and would spill into the next one
+func (r Range) Allocate(source int) (int, error) {
+	if source < 0 || source >= r.Size {
+		return 0, fmt.Errorf("%s %d does not fit in %s (%d - %d)", r.Kind, source, r.Name, r.Base, r.Base+r.Size-1)
+	}
+	return r.ID(source), nil
+}
+
+func (r Range) String() string {
+	return fmt.Sprintf("%s: %s %d - %d in %s", r.Name, r.Kind, r.Base, r.Base+r.Size-1, r.Table)
+}
+
+// Registry holds the declared ranges and refuses any that overlap
+type Registry struct {
+	ranges []Range
+}
+
+func (reg *Registry) Declare(r Range) error {
+	if r.Size <= 0 || r.Base <= 0 {
+		return fmt.Errorf("range %s needs a base and a size", r.Name)
+	}
+	if _, ok := sources[r.Kind]; !ok {
+		return fmt.Errorf("range %s has unknown kind %q", r.Name, r.Kind)
+	}
+	for _, declared := range reg.ranges {
+		if declared.Name == r.Name {
+			return fmt.Errorf("range %s is already declared", r.Name)
+		}
+		if declared.Overlaps(r) {
+			return fmt.Errorf("range %s overlaps %s", r, declared)
+		}
+	}
+	reg.ranges = append(reg.ranges, r)
+	return nil
+}
+
+// Ranges sorted by kind then base
+func (reg *Registry) Ranges() []Range {
+	ranges := append([]Range{}, reg.ranges...)
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].Kind != ranges[j].Kind {
+			return ranges[i].Kind < ranges[j].Kind
+		}
+		return ranges[i].Base < ranges[j].Base
+	})
+	return ranges
+}
+
+// GeneratedBase is the lowest id of kind any product line writes to, rows below it are source rows
+func (reg *Registry) GeneratedBase(kind Kind) int {
+	base := 0
+	for _, r := range reg.ranges {
+		if r.Kind == kind && (base == 0 || r.Base < base) {
+			base = r.Base
+		}
+	}
+	return base
+}
+
+var registry = &Registry{}
+
+func declare(r Range) Range {
+	if err := registry.Declare(r); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Every range the tools write to. Each product line gets its own so no tool can write over another's rows.
+var (
+	VendorItems    = declare(Range{Name: "emblem vendor items", Kind: Items, Base: 2000000, Size: 1000000, Table: "item_template_new_vendor"})
+	MythicItems    = declare(Range{Name: "dungeon mythic items", Kind: Items, Base: 20000000, Size: 1000000, Table: "item_template", Match: "displayid"})
+	LegendaryItems = declare(Range{Name: "dungeon legendary items", Kind: Items, Base: 21000000, Size: 1000000, Table: "item_template", Match: "displayid"})
+	AscendantItems = declare(Range{Name: "dungeon ascendant items", Kind: Items, Base: 22000000, Size: 1000000, Table: "item_template", Match: "displayid"})
+	RaidItems      = declare(Range{Name: "raid-gear items", Kind: Items, Base: 23000000, Size: 1000000, Table: "item_template", Match: "displayid"})
+
+	VendorSpells    = declare(Range{Name: "emblem vendor spells", Kind: Spells, Base: 3000000, Size: 1000000, Table: "spells_new_vendor", Match: "SpellIconID"})
+	RareSpells      = declare(Range{Name: "dungeon rare spells", Kind: Spells, Base: 30000000, Size: 1000000, Table: "spell_dbc", Match: "SpellIconID"})
+	EpicSpells      = declare(Range{Name: "dungeon epic spells", Kind: Spells, Base: 31000000, Size: 1000000, Table: "spell_dbc", Match: "SpellIconID"})
+	LegendarySpells = declare(Range{Name: "dungeon legendary spells", Kind: Spells, Base: 32000000, Size: 1000000, Table: "spell_dbc", Match: "SpellIconID"})
+	RaidSpells      = declare(Range{Name: "raid-gear spells", Kind: Spells, Base: 33000000, Size: 1000000, Table: "spell_dbc", Match: "SpellIconID"})
+
+	// scaled copies of a spell with other effect values than the first one, see Map
+	DungeonSpellVariants = declare(Range{Name: "dungeon spell variants", Kind: Spells, Base: 34000000, Size: 1000000, Table: "spell_dbc", Match: "SpellIconID"})
+	RaidSpellVariants    = declare(Range{Name: "raid-gear spell variants", Kind: Spells, Base: 35000000, Size: 1000000, Table: "spell_dbc", Match: "SpellIconID"})
+)
+
+// Declared returns every range the tools write to
+func Declared() []Range {
+	return registry.Ranges()
+}
+
+// GeneratedBase is the first generated id of kind, source queries should stay below it
+func GeneratedBase(kind Kind) int {
+	return registry.GeneratedBase(kind)
+}
+
+// Line is the ranges one tool writes to, items by difficulty and spells by quality
+type Line struct {
+	Name     string
+	items    map[int]Range // 0 is every difficulty not listed
+	spells   map[int]Range // 0 is every quality not listed
+	variants Range         // spells that scaled to other values than the first copy
+}
+
+var (
+	Dungeon = Line{
+		Name:     "dungeon",
+		items:    map[int]Range{0: MythicItems, 4: LegendaryItems, 5: AscendantItems},
+		spells:   map[int]Range{0: RareSpells, 4: EpicSpells, 5: LegendarySpells},
+		variants: DungeonSpellVariants,
+	}
+	RaidGear = Line{
+		Name:     "raid-gear",
+		items:    map[int]Range{0: RaidItems},
+		spells:   map[int]Range{0: RaidSpells},
+		variants: RaidSpellVariants,
+	}
+	EmblemVendor = Line{
+		Name:   "emblem vendor",
+		items:  map[int]Range{0: VendorItems},
+		spells: map[int]Range{0: VendorSpells},
+	}
+)
+
+func (l Line) Item(difficulty int) Range {
+	if r, ok := l.items[difficulty]; ok {
+		return r
+	}
+	return l.items[0]
+}
+
+func (l Line) Spell(quality int) Range {
+	if r, ok := l.spells[quality]; ok {
+		return r
+	}
+	return l.spells[0]
+}
+
+func (l Line) SpellVariants() Range {
+	return l.variants
+}
+
+var current = Dungeon
+
+// Use makes line the one GeneratedEntry and ScaledSpellId hand out ids from
+func Use(line Line) {
+	current = line
+}
+
+func Current() Line {
+	return current
+}
+
+// ConflictError is an id that is outside the range it is written to or already taken by a row from somewhere else
+type ConflictError struct {
+	Range  Range
+	Source int
+	ID     int
+	Reason string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("id conflict in %s: %s %d generated from %d %s", e.Range.Name, e.Range.Kind, e.ID, e.Source, e.Reason)
+}
+
+// Check is called before the row generated from source is written to id, it finds the declared range
+// id is in and checks it there
+func Check(db sqlrow.Querier, kind Kind, source, id int) error {
+	for _, r := range registry.ranges {
+		if r.Kind == kind && r.Contains(id) {
+			return r.Check(db, source, id)
+		}
+	}
+	return fmt.Errorf("%s %d generated from %d is not in any declared range", kind, id, source)
+}
+
+// Check is called before the row generated from source is written to id. It fails when id is outside
+// the range or when a row already on id was not generated from source.
+func (r Range) Check(db sqlrow.Querier, source, id int) error {
+	if !r.Contains(id) {
+		return &ConflictError{Range: r, Source: source, ID: id, Reason: "is outside the range"}
+	}
+	if r.Match == "" {
+		return nil
+	}
+
+	src := sources[r.Kind]
+	var occupant sql.NullString
+	err := db.Get(&occupant, fmt.Sprintf("SELECT %s FROM %s WHERE %s = ?", r.Match, r.Table, src.key), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check %s %d in %s: %w", r.Table, id, r.Name, err)
+	}
+
+	var original sql.NullString
+	err = db.Get(&original, fmt.Sprintf("SELECT %s FROM %s WHERE %s = ?", r.Match, src.table, src.key), source)
+	if errors.Is(err, sql.ErrNoRows) {
+		// nothing to copy so nothing gets written over the occupant
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s %d: %w", src.table, source, err)
+	}
+
+	if occupant != original {
+		return &ConflictError{Range: r, Source: source, ID: id,
+			Reason: fmt.Sprintf("is taken by a row with %s %q, the source has %q", r.Match, occupant.String, original.String)}
+	}
+	return nil
+}
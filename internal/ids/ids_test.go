@@ -0,0 +1,117 @@
+package ids
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestDeclareRefusesOverlaps(t *testing.T) {
+	reg := &Registry{}
+	if err := reg.Declare(Range{Name: "mythic", Kind: Items, Base: 20000000, Size: 1000000}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		r     Range
+		valid bool
+	}{
+		{"next block", Range{Name: "legendary", Kind: Items, Base: 21000000, Size: 1000000}, true},
+		{"same ids as spells", Range{Name: "spells", Kind: Spells, Base: 20000000, Size: 1000000}, true},
+		{"inside", Range{Name: "raid", Kind: Items, Base: 20500000, Size: 10}, false},
+		{"runs into", Range{Name: "vendor", Kind: Items, Base: 19999999, Size: 2}, false},
+		{"same name", Range{Name: "mythic", Kind: Items, Base: 40000000, Size: 1}, false},
+		{"empty", Range{Name: "empty", Kind: Items, Base: 50000000}, false},
+		{"unknown kind", Range{Name: "quests", Kind: "quest", Base: 60000000, Size: 1}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := reg.Declare(test.r)
+			if test.valid && err != nil {
+				t.Errorf("expected %s to be declared, got %v", test.r, err)
+			}
+			if !test.valid && err == nil {
+				t.Errorf("expected %s to be refused", test.r)
+			}
+		})
+	}
+}
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		line       Line
+		difficulty int
+		quality    int
+		entry      int
+		spell      int
+	}{
+		{Dungeon, 3, 3, 20000100, 30007597},
+		{Dungeon, 4, 4, 21000100, 31007597},
+		{Dungeon, 5, 5, 22000100, 32007597},
+		{Dungeon, 1, 2, 20000100, 30007597},
+		{RaidGear, 3, 4, 23000100, 33007597},
+		{EmblemVendor, 3, 4, 2000100, 3007597},
+	}
+	for _, test := range tests {
+		if entry := test.line.Item(test.difficulty).ID(100); entry != test.entry {
+			t.Errorf("%s difficulty %d: expected entry %d, got %d", test.line.Name, test.difficulty, test.entry, entry)
+		}
+		if spell := test.line.Spell(test.quality).ID(7597); spell != test.spell {
+			t.Errorf("%s quality %d: expected spell %d, got %d", test.line.Name, test.quality, test.spell, spell)
+		}
+	}
+
+	if base := GeneratedBase(Items); base != 2000000 {
+		t.Errorf("expected generated items to start at 2000000, got %d", base)
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	if id, err := MythicItems.Allocate(999999); err != nil || id != 20999999 {
+		t.Errorf("expected 20999999, got %d %v", id, err)
+	}
+	if _, err := MythicItems.Allocate(1000000); err == nil {
+		t.Error("expected an entry that spills into the legendary range to fail")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	db, err := sqlx.Open("sqlite3", t.TempDir()+"/world.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	db.MustExec("CREATE TABLE item_template (entry INTEGER PRIMARY KEY, name TEXT, displayid INTEGER)")
+	db.MustExec("INSERT INTO item_template VALUES (100, 'Sword', 5), (101, 'Axe', 6), (102, 'Mace', 7)")
+	db.MustExec("INSERT INTO item_template VALUES (20000100, 'Mythic Sword', 5), (20000101, 'Custom Hat', 9)")
+
+	tests := []struct {
+		name     string
+		source   int
+		id       int
+		conflict bool
+	}{
+		{"earlier copy", 100, 20000100, false},
+		{"taken by another row", 101, 20000101, true},
+		{"free", 102, 20000102, false},
+		{"no source row", 103, 20000103, false},
+		{"wrong id", 102, 21000102, true},
+		{"too big", 1000005, 21000005, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := MythicItems.Check(db, test.source, test.id)
+			var conflict *ConflictError
+			if test.conflict && !errors.As(err, &conflict) {
+				t.Errorf("expected a conflict, got %v", err)
+			}
+			if !test.conflict && err != nil {
+				t.Errorf("expected no conflict, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package ids
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const mapVersion = 1
+
+// Map keeps the id every variant of a source row was given so a rerun writes the same rows to the same ids.
+// A variant is whatever makes two generated copies of one source row different, the scaled effect values of a spell.
+type Map struct {
+	Version int            `json:"version"`
+	Ids     map[string]int `json:"ids"`
+
+	path string
+	used map[int]bool
+}
+
+func NewMap(path string) *Map {
+	return &Map{
+		Version: mapVersion,
+		Ids:     map[string]int{},
+		path:    path,
+		used:    map[int]bool{},
+	}
+}
+
+// LoadMap reads the map saved at path, a missing file is an empty map
+func LoadMap(path string) (*Map, error) {
+	m := NewMap(path)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read id map %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse id map %s: %w", path, err)
+	}
+	if m.Version != mapVersion {
+		return nil, fmt.Errorf("id map %s is version %d, expected %d", path, m.Version, mapVersion)
+	}
+	if m.Ids == nil {
+		m.Ids = map[string]int{}
+	}
+	for key, id := range m.Ids {
+		if m.used[id] {
+			return nil, fmt.Errorf("id map %s gives %d to more than one variant, %s is one of them", path, id, key)
+		}
+		m.used[id] = true
+	}
+	return m, nil
+}
+
+// Allocate returns the id for one variant of source in r. The first variant of a source gets the range's
+// own id for it (r.ID(source)), every other variant gets the next free id in variants.
+func (m *Map) Allocate(r, variants Range, source int, variant string) (int, error) {
+	key := fmt.Sprintf("%s/%d/%s", r.Name, source, variant)
+	if id, ok := m.Ids[key]; ok {
+		return id, nil
+	}
+
+	id, err := r.Allocate(source)
+	if err != nil {
+		return 0, err
+	}
+
+	if m.used[id] {
+		if variants.Size == 0 {
+			return 0, fmt.Errorf("%s %d needs another id in %s but the range has no variant range", r.Kind, source, r.Name)
+		}
+		id = variants.Base
+		for m.used[id] {
+			id++
+		}
+		if !variants.Contains(id) {
+			return 0, fmt.Errorf("%s is full", variants.Name)
+		}
+	}
+
+	m.Ids[key] = id
+	m.used[id] = true
+	return id, nil
+}
+
+func (m *Map) Len() int {
+	return len(m.Ids)
+}
+
+// Save writes the map back to the path it was loaded from
+func (m *Map) Save() error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(m.path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write id map %s: %w", m.path, err)
+	}
+	return nil
+}
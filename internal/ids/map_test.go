@@ -0,0 +1,67 @@
+package ids
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestMapAllocate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spell-ids.json")
+	m, err := LoadMap(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	allocations := []struct {
+		r        Range
+		source   int
+		variant  string
+		expected int
+	}{
+		{EpicSpells, 21919, "120,0", 31021919},
+		{EpicSpells, 21919, "250,0", 34000000},
+		{EpicSpells, 21919, "120,0", 31021919},
+		{EpicSpells, 7597, "0,0", 31007597},
+		{EpicSpells, 21919, "300,0", 34000001},
+		{RareSpells, 21919, "300,0", 30021919},
+	}
+	for _, a := range allocations {
+		id, err := m.Allocate(a.r, DungeonSpellVariants, a.source, a.variant)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != a.expected {
+			t.Errorf("%s %d %s: expected %d, got %d", a.r.Name, a.source, a.variant, a.expected, id)
+		}
+	}
+	if m.Len() != 5 {
+		t.Errorf("expected 5 ids, got %d", m.Len())
+	}
+
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a rerun sees the variants in another order and still gets the same ids
+	reloaded, err := LoadMap(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := len(allocations) - 1; i >= 0; i-- {
+		a := allocations[i]
+		id, err := reloaded.Allocate(a.r, DungeonSpellVariants, a.source, a.variant)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id != a.expected {
+			t.Errorf("reloaded %s %d %s: expected %d, got %d", a.r.Name, a.source, a.variant, a.expected, id)
+		}
+	}
+
+	if _, err := reloaded.Allocate(VendorSpells, Range{}, 7597, "1,0"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reloaded.Allocate(VendorSpells, Range{}, 7597, "2,0"); err == nil {
+		t.Error("expected a second variant without a variant range to fail")
+	}
+}
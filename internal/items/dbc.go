@@ -0,0 +1,59 @@
+package items
+
+import (
+	"fmt"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/dbc"
+)
+
+// ItemDbc builds the client Item.dbc patch so generated entries show the right model without
+// waiting on the server item cache. Rows are built from the item_template values written to the server.
+type ItemDbc struct {
+	*dbc.Patch
+}
+
+// OpenItemDbc loads the client Item.dbc the generated items are added to
+func OpenItemDbc(path string) (*ItemDbc, error) {
+	patch, err := dbc.OpenPatch(path, dbc.ItemSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ItemDbc{patch}, nil
+}
+
+func NewItemDbc(file *dbc.File) *ItemDbc {
+	return &ItemDbc{dbc.NewPatch(file)}
+}
+
+// Add writes the Item.dbc row for item under newEntry, an entry already in the client Item.dbc is an error
+func (d *ItemDbc) Add(item mysql.DbItem, newEntry int) error {
+	record, err := d.Create(uint32(newEntry))
+	if err != nil {
+		return fmt.Errorf("generated item %v (%v): %w", item.Name, item.Entry, err)
+	}
+
+	// -1 is no override in both item_template and Item.dbc
+	soundOverride := -1
+	if item.SoundOverride != nil {
+		soundOverride = *item.SoundOverride
+	}
+
+	record.SetUint32("ClassID", uint32(intValue(item.Class)))
+	record.SetUint32("SubclassID", uint32(intValue(item.Subclass)))
+	record.SetInt32("Sound_Override_Subclassid", int32(soundOverride))
+	record.SetInt32("Material", int32(intValue(item.Material)))
+	record.SetUint32("DisplayInfoID", uint32(item.DisplayId))
+	record.SetUint32("InventoryType", uint32(intValue(item.InventoryType)))
+	record.SetUint32("SheatheType", uint32(intValue(item.Sheath)))
+
+	return nil
+}
+
+func intValue(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
@@ -0,0 +1,74 @@
+package items
+
+import (
+	"testing"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/dbc"
+)
+
+func TestGeneratedEntry(t *testing.T) {
+	tests := []struct {
+		difficulty int
+		expected   int
+	}{
+		{3, 20012345},
+		{4, 21012345},
+		{5, 22012345},
+	}
+
+	for _, tt := range tests {
+		if got := GeneratedEntry(12345, tt.difficulty); got != tt.expected {
+			t.Errorf("GeneratedEntry(12345, %d) = %d, want %d", tt.difficulty, got, tt.expected)
+		}
+	}
+}
+
+func TestItemDbcAdd(t *testing.T) {
+	file := dbc.New(dbc.ItemSchema)
+	file.Add().SetUint32("ID", 20000001)
+	itemDbc := NewItemDbc(file)
+
+	item := mysql.DbItem{
+		Entry:         12345,
+		Name:          "Ironfoe",
+		DisplayId:     22906,
+		Class:         ptrInt(2),
+		Subclass:      ptrInt(4),
+		Material:      ptrInt(1),
+		InventoryType: ptrInt(21),
+		Sheath:        ptrInt(3),
+	}
+
+	if err := itemDbc.Add(item, GeneratedEntry(item.Entry, 3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := itemDbc.Add(mysql.DbItem{Entry: 1}, GeneratedEntry(1, 3)); err == nil {
+		t.Error("expected an error adding an entry already in Item.dbc")
+	}
+
+	record := file.Find(20012345)
+	if record == nil {
+		t.Fatal("item row not written")
+	}
+
+	tests := []struct {
+		column   string
+		got      int64
+		expected int64
+	}{
+		{"ClassID", int64(record.Uint32("ClassID")), 2},
+		{"SubclassID", int64(record.Uint32("SubclassID")), 4},
+		{"Sound_Override_Subclassid", int64(record.Int32("Sound_Override_Subclassid")), -1},
+		{"Material", int64(record.Int32("Material")), 1},
+		{"DisplayInfoID", int64(record.Uint32("DisplayInfoID")), 22906},
+		{"InventoryType", int64(record.Uint32("InventoryType")), 21},
+		{"SheatheType", int64(record.Uint32("SheatheType")), 3},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.expected {
+			t.Errorf("%s = %d, want %d", tt.column, tt.got, tt.expected)
+		}
+	}
+}
@@ -318,8 +318,8 @@ func TestScaleDPS(t *testing.T) {
 				},
 			},
 			level:       25,
-			wantDPSMin:  21.0, // Expected DPS range due to randomness
-			wantDPSMax:  42.0,
+			wantDPSMin:  280.0, // scaled from level 25 to 100, four times the level on a 1.0 speed weapon
+			wantDPSMax:  300.0,
 			expectError: false,
 		},
 		{
@@ -407,7 +407,7 @@ func TestGetDpsModifier(t *testing.T) {
 					Quality:  ptrInt(3), // Rare
 				},
 			},
-			wantModifier: 0.64 * 1.38,
+			wantModifier: 0.58 * 1.2,
 			expectError:  false,
 		},
 		{
@@ -418,7 +418,7 @@ func TestGetDpsModifier(t *testing.T) {
 					Quality:  ptrInt(4),  // Epic
 				},
 			},
-			wantModifier: 0.80 * 1.5,
+			wantModifier: 0.85 * 1.5,
 			expectError:  false,
 		},
 		{
@@ -429,7 +429,7 @@ func TestGetDpsModifier(t *testing.T) {
 					Quality:  ptrInt(2), // Uncommon
 				},
 			},
-			wantModifier: 0.70 * 1.25,
+			wantModifier: 0.70 * 1.0,
 			expectError:  false,
 		},
 		{
@@ -3,16 +3,20 @@ package items
 import (
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"math"
-	"math/rand/v2"
 	"reflect"
 	"slices"
 	"strings"
-	"time"
 
 	"github.com/araxiaonline/endgame-item-generator/internal/config"
 	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlrow"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/store"
+	"github.com/araxiaonline/endgame-item-generator/internal/ids"
+	"github.com/araxiaonline/endgame-item-generator/internal/logging"
+	"github.com/araxiaonline/endgame-item-generator/internal/naming"
+	"github.com/araxiaonline/endgame-item-generator/internal/rng"
 	"github.com/araxiaonline/endgame-item-generator/internal/spells"
 )
 
@@ -26,6 +30,7 @@ type Item struct {
 	ConvStatCount int
 	Spells        []spells.Spell
 	Difficulty    int
+	Map           int // dungeon or raid the item drops in, picks the name theme
 }
 
 // Use for storing item stats for all stats that will be scaled.
@@ -43,6 +48,8 @@ type StatScaleParams struct {
 	ItemType     int
 	StatTypeId   int
 	StatValue    int
+	Percent      float64 // share of the item stat budget, from GetStatPercents
+	Difficulty   int
 }
 
 // Create a new item from the database item with deep copy to prevent shared pointer issues
@@ -217,13 +224,22 @@ func (item *Item) SetDifficulty(difficulty int) {
 	item.Difficulty = difficulty
 }
 
+func (item *Item) SetMap(mapId int) {
+	item.Map = mapId
+}
+
+// logger tags the records of stage with the item's entry and difficulty
+func (item *Item) logger(stage string) *slog.Logger {
+	return slog.With(logging.Entry, item.Entry, logging.Difficulty, item.Difficulty, logging.Stage, stage)
+}
+
 // scaleArmor calculates and updates the item's armor value based on its level, quality, and material subclass.
 // It checks for nil pointers for critical scaling fields and valid map keys before performing calculations.
 func (item *Item) ScaleArmor(itemLevel int) {
 	// Ensure critical pointer fields for scaling are non-nil
 	// Entry and Name are value types from the embedded DbItem and used for logging.
 	if item.Class == nil || item.Armor == nil || item.Quality == nil || item.Subclass == nil || item.Material == nil {
-		log.Printf("Item (Entry: %d, Name: '%s'): Cannot scale armor: one or more required pointer fields (Class, Armor, Quality, Subclass, Material) are nil.", item.Entry, item.Name)
+		item.logger("armor").Warn("cannot scale armor, one of Class, Armor, Quality, Subclass or Material is not set", "name", item.Name)
 		return
 	}
 
@@ -248,8 +264,7 @@ func (item *Item) ScaleArmor(itemLevel int) {
 			if !mOk {
 				errorMessages = append(errorMessages, fmt.Sprintf("invalid Subclass key for MaterialModifier: %d", *item.Subclass))
 			}
-			log.Printf("Item (Entry: %d, Name: '%s'): Could not scale armor. Issues: %s. Original Armor: %d",
-				item.Entry, item.Name, strings.Join(errorMessages, "; "), *item.Armor)
+			item.logger("armor").Warn("could not scale armor", "name", item.Name, "issues", strings.Join(errorMessages, "; "), "armor", *item.Armor)
 		}
 	}
 }
@@ -263,7 +278,7 @@ func (item Item) GetPrimaryStat() (int, int, error) {
 
 		statType, err := item.GetField(fmt.Sprintf("StatType%v", i))
 		if err != nil {
-			log.Printf("Failed to get stat type %v for item: %v", i, item.Name)
+			item.logger("stats").Warn("failed to get stat type", "slot", i, "err", err)
 			continue
 		}
 		if statType < 3 || statType > 7 {
@@ -273,7 +288,7 @@ func (item Item) GetPrimaryStat() (int, int, error) {
 		val, err := item.GetField(fmt.Sprintf("StatValue%v", i))
 
 		if err != nil {
-			log.Printf("Failed to get stat value %v for item: %v", i, item.Name)
+			item.logger("stats").Warn("failed to get stat value", "slot", i, "err", err)
 			continue
 		}
 		if val == 0 {
@@ -300,14 +315,14 @@ func (item Item) GetStatList() ([]int, error) {
 	// Also need to get spells that on the item that convert to stats
 	spells, err := item.GetSpells()
 	if err != nil {
-		log.Printf("Failed to get spells for item: %v", err)
+		item.logger("stats").Error("failed to get spells", "err", err)
 		return nil, err
 	}
 
 	for _, spell := range spells {
 		convStats, err := spell.ConvertToStats()
 		if err != nil {
-			log.Printf("Failed to convert spell to stats: %v for spell %v", err, spell.Name)
+			item.logger("stats").Debug("spell does not convert to stats", logging.Spell, spell.ID, "err", err)
 			continue
 		}
 
@@ -325,7 +340,7 @@ func (item Item) GetStatList() ([]int, error) {
 		val, err := item.GetField(fmt.Sprintf("StatValue%v", i))
 
 		if err != nil {
-			log.Printf("Failed to get stat value %v for item: %v", i, item.Name)
+			item.logger("stats").Warn("failed to get stat value", "slot", i, "err", err)
 			continue
 		}
 		if val == 0 {
@@ -334,7 +349,7 @@ func (item Item) GetStatList() ([]int, error) {
 
 		statId, err := item.GetField(fmt.Sprintf("StatType%v", i))
 		if err != nil {
-			log.Printf("Failed to get stat type %v for item: %s", i, item.Name)
+			item.logger("stats").Warn("failed to get stat type", "slot", i, "err", err)
 			continue
 		}
 		statList = append(statList, statId)
@@ -414,8 +429,7 @@ func (item *Item) ScaleDPS(oldLevel, level int) (float64, error) {
 
 	modifier, err := item.GetDpsModifier()
 	if err != nil {
-		log.Fatalf("Error getting DPS modifier: %v", err)
-		return 0.0, err
+		return 0.0, fmt.Errorf("failed to get the dps modifier: %w", err)
 	}
 
 	scalingFactor := math.Pow(float64(level)/float64(oldLevel), 1.012)
@@ -432,7 +446,7 @@ func (item *Item) ScaleDPS(oldLevel, level int) (float64, error) {
 	maximum := adjDps * float64(maxMod)
 
 	// If the weapon has secondary damage, scale that as well based on the ratio of the primary damage
-	if *item.MinDmg2 != 0 && *item.MaxDmg2 != 0 {
+	if item.MinDmg2 != nil && item.MaxDmg2 != nil && *item.MinDmg2 != 0 && *item.MaxDmg2 != 0 {
 		ratioMin := float64(*item.MinDmg2) / float64(*item.MinDmg1)
 		ratioMax := float64(*item.MaxDmg2) / float64(*item.MaxDmg1)
 		minimum2 := ratioMin * float64(minimum)
@@ -540,10 +554,10 @@ func (item *Item) GetSpells() ([]spells.Spell, error) {
 	}
 
 	spellList := []spells.Spell{}
-	values := reflect.ValueOf(item)
 	for i := 1; i < 4; i++ {
-		spellId := values.Elem().FieldByName(fmt.Sprintf("SpellId%v", i)).Elem().Int()
-		if spellId == 0 {
+		// unset spell slots are nil on items that were not loaded from the database
+		spellId, err := item.GetField(fmt.Sprintf("SpellId%v", i))
+		if err != nil || spellId == 0 {
 			continue
 		}
 
@@ -551,14 +565,14 @@ func (item *Item) GetSpells() ([]spells.Spell, error) {
 			continue
 		}
 
-		db, err := mysql.GetDb()
+		db, err := store.GetStore()
 		if err != nil {
 			return nil, err
 		}
 
-		dbspell, err := db.GetSpell(int(spellId))
+		dbspell, err := db.GetSpell(spellId)
 		if err != nil {
-			log.Printf("failed to get the spell: %v error: %v", spellId, err)
+			item.logger("spells").Warn("failed to get the spell", logging.Spell, spellId, "err", err)
 			continue
 		}
 		spell := spells.Spell{
@@ -578,7 +592,7 @@ func (item *Item) GetNonStatSpells() ([]spells.Spell, error) {
 		spellId, err := item.GetField(fmt.Sprintf("SpellId%v", i))
 
 		if err != nil {
-			log.Printf("Failed to get spell id %v", i)
+			item.logger("spells").Warn("failed to get spell id", "slot", i, "err", err)
 			continue
 		}
 
@@ -586,14 +600,14 @@ func (item *Item) GetNonStatSpells() ([]spells.Spell, error) {
 			continue
 		}
 
-		db, err := mysql.GetDb()
+		db, err := store.GetStore()
 		if err != nil {
 			return nil, err
 		}
 
 		dbSpell, err := db.GetSpell(spellId)
 		if err != nil {
-			log.Printf("Failed to get spell %v", spellId)
+			item.logger("spells").Warn("failed to get the spell", logging.Spell, spellId, "err", err)
 			continue
 		}
 
@@ -678,22 +692,23 @@ func (item *Item) ScaleItem(itemLevel int, itemQuality int) (bool, error) {
 		*item.Quality = itemQuality
 	}
 
-	log.Printf("Scaling item %v %v to item level %v and quality %v", item.Name, item.Entry, itemLevel, *item.Quality)
+	logger := item.logger("scale")
+	logger.Debug("scaling item", "name", item.Name, "from", fromItemLevel, "itemLevel", itemLevel, "quality", *item.Quality)
 
 	// Get all the spell Stats on the item we can convert
 	spellList, err := item.GetSpells()
 	if err != nil {
-		log.Printf("Failed to get spells for item: %v", err)
+		logger.Error("failed to get spells", "err", err)
 		return false, err
 	}
 
 	for i := 0; i < len(spellList); i++ {
 
-		log.Printf("Spell %v (%v) Effect %v AuraEffect %v Spell Desc: %v basePoints %v", spellList[i].Name, spellList[i].ID, spellList[i].Effect1, spellList[i].EffectAura1, spellList[i].Description, spellList[i].EffectBasePoints1)
+		logger.Debug("item spell", logging.Spell, spellList[i].ID, "name", spellList[i].Name, "effect", spellList[i].Effect1, "aura", spellList[i].EffectAura1, "basePoints", spellList[i].EffectBasePoints1)
 
 		convStats, err := spellList[i].ConvertToStats()
 		if err != nil {
-			log.Printf("Failed to convert spell to stats: %v for spell %v", err, spellList[i].Name)
+			logger.Debug("spell does not convert to stats", logging.Spell, spellList[i].ID, "err", err)
 			continue
 		}
 
@@ -707,7 +722,13 @@ func (item *Item) ScaleItem(itemLevel int, itemQuality int) (bool, error) {
 
 	allStats := item.GetStatPercents(allSpellStats)
 
-	for statId, stat := range allStats {
+	// stats are walked in id order so the output does not change between runs
+	for _, statId := range sortedStatIds(allStats) {
+		stat, ok := allStats[statId]
+		if !ok {
+			// merged into attack or spell power by correctSpellAttackPower
+			continue
+		}
 		origValue := stat.Value
 
 		scaleParams := StatScaleParams{
@@ -717,10 +738,11 @@ func (item *Item) ScaleItem(itemLevel int, itemQuality int) (bool, error) {
 			ItemType:     *item.InventoryType,
 			StatTypeId:   statId,
 			StatValue:    stat.Value,
+			Percent:      stat.Percent,
+			Difficulty:   item.GetDifficulty(),
 		}
 
-		stat.Value = scaleStatv3(scaleParams, item.GetDifficulty())
-		// stat.Value = scaleStatv2(itemLevel, *item.InventoryType, *item.Quality, stat.Percent, config.StatModifiers[statId])
+		stat.Value = currentScaler.ScaleStat(scaleParams)
 
 		if statId == STAT.SpellPower && stat.Value < 100 {
 			stat.Value = int(math.Round(float64(stat.Value) * 2.3785))
@@ -728,28 +750,30 @@ func (item *Item) ScaleItem(itemLevel int, itemQuality int) (bool, error) {
 
 		correctSpellAttackPower(item, allStats)
 
-		log.Printf(">>>>>> Scaled : StatId: %v Type: %s Orig: %v - New Value: %v Percent: %v", statId, stat.Type, origValue, stat.Value, stat.Percent)
+		logger.Debug("scaled stat", "stat", statId, "type", stat.Type, "from", origValue, "to", stat.Value, "percent", stat.Percent)
 	}
 
 	item.addStats(allStats)
 	*item.StatsCount = len(allStats)
+	item.StatsMap = allStats
 
 	// Scale Armor Stats
 	item.ScaleArmor(itemLevel)
 
 	// If the item is a weapon scale the DPS
 	if *item.Class == 2 && *item.MinDmg1 > 0 {
+		dpsLogger := item.logger("dps")
 		predps, err := item.GetDPS()
 		if err != nil {
-			log.Printf("Failed to get DPS: %v", err)
+			dpsLogger.Warn("failed to get dps", "err", err)
 		}
 
 		dps, err := item.ScaleDPS(fromItemLevel, itemLevel)
 		if err != nil {
-			log.Printf("Failed to scale DPS: %v", err)
+			dpsLogger.Error("failed to scale dps", "err", err)
 			return false, err
 		}
-		log.Printf("DPS: %.1f scaled up from previous dps %v: Min %v - Max %v", dps, predps, *item.MinDmg1, *item.MaxDmg1)
+		dpsLogger.Debug("scaled dps", "from", predps, "to", dps, "min", *item.MinDmg1, "max", *item.MaxDmg1)
 	}
 
 	item.cleanSpells()
@@ -763,12 +787,13 @@ func (item *Item) ScaleItem(itemLevel int, itemQuality int) (bool, error) {
 	// from having the extra damage.  This could really create some unique sought after weapons that exploit this.
 	// modified ratio ((s1 / existing iLevel) * newIlevel) * (0.20 Rare or 0.30 Epic or 0.4 for Legendary).
 
+	spellLogger := item.logger("spells")
 	otherSpells, err := item.GetNonStatSpells()
 	if err != nil {
-		log.Printf("failed to get non stat spells: %v", err)
+		spellLogger.Warn("failed to get non stat spells", "err", err)
 	}
 
-	log.Printf("\n\n\n -------------------- COUNT OF other spells %v \n\n", len(otherSpells))
+	spellLogger.Debug("spells to scale", "count", len(otherSpells))
 
 	item.Spells = []spells.Spell{}
 	// Spells that can not be scaled into stats must get new spells scaled and created
@@ -787,10 +812,10 @@ func (item *Item) ScaleItem(itemLevel int, itemQuality int) (bool, error) {
 			tier = 2
 		}
 
-		log.Printf("Scaling spell %v (ID: %v) with tier %d modifier", spell.Name, spell.ID, tier)
+		spellLogger.Debug("scaling spell", logging.Spell, spell.ID, "name", spell.Name, "tier", tier)
 		err := spell.ForceScaleSpell(fromItemLevel, itemLevel, *item.Quality, tier)
 		if err != nil {
-			log.Printf("Failed to scale spell: %v, Spell %v", err, spell.ID)
+			spellLogger.Warn("failed to scale spell", logging.Spell, spell.ID, "err", err)
 			continue
 		}
 
@@ -825,12 +850,19 @@ func (item *Item) GetField(fieldName string) (int, error) {
 	}
 }
 
+// UseSpellWriteIds points each spell slot of the item at the id its scaled spell is written with
+func (item *Item) UseSpellWriteIds() {
+	for _, spell := range item.Spells {
+		item.UpdateField(fmt.Sprintf("SpellId%v", spell.ItemSpellSlot), spell.WriteId(*item.Quality))
+	}
+}
+
 // Updates a dynamic field on the item struct useful for stat replacements or spells
 func (item *Item) UpdateField(fieldName string, value int) {
 	itemValue := reflect.ValueOf(item).Elem()
 	field := itemValue.FieldByName(fieldName)
 	if !field.IsValid() {
-		log.Printf("failed to find field %s", fieldName)
+		item.logger("update").Warn("failed to find field", "field", fieldName)
 		return
 	}
 
@@ -870,9 +902,10 @@ func (item *Item) cleanSpells() {
 	for i := 1; i < 3; i++ {
 		currentId, err := item.GetField(fmt.Sprintf("SpellId%v", i))
 
-		log.Printf("Checking spell id %v - value %v", i, currentId)
+		logger := item.logger("clean-spells")
+		logger.Debug("checking spell slot", "slot", i, logging.Spell, currentId)
 		if err != nil {
-			log.Printf("ERROR: Failed to get spell id %v err: %v", i, err)
+			logger.Error("failed to get spell id", "slot", i, "err", err)
 			continue
 		}
 
@@ -880,13 +913,13 @@ func (item *Item) cleanSpells() {
 		if currentId == 0 {
 			nextSpellId, err := item.GetField(fmt.Sprintf("SpellId%v", i+1))
 			if err != nil {
-				log.Printf("ERROR: Failed to get spell id %v err: %v", i+1, err)
+				logger.Error("failed to get spell id", "slot", i+1, "err", err)
 			}
 
 			if nextSpellId != 0 {
 				item.UpdateField(fmt.Sprintf("SpellId%v", i), nextSpellId)
 				item.UpdateField(fmt.Sprintf("SpellId%v", i+1), 0)
-				log.Printf("Moved spell %v to %v to replace removed spell", nextSpellId, i)
+				logger.Debug("moved spell to replace a removed one", logging.Spell, nextSpellId, "slot", i)
 				continue
 			}
 
@@ -902,7 +935,8 @@ func (item *Item) addStats(stats map[int]*ItemStat) {
 
 	// itemValue := reflect.ValueOf(item).Elem() // Get value of underlying struct
 
-	for statId, stat := range stats {
+	for _, statId := range sortedStatIds(stats) {
+		stat := stats[statId]
 		if i > 10 {
 			break
 		}
@@ -944,6 +978,15 @@ func (item *Item) addStats(stats map[int]*ItemStat) {
 	}
 }
 
+func sortedStatIds(stats map[int]*ItemStat) []int {
+	ids := make([]int, 0, len(stats))
+	for statId := range stats {
+		ids = append(ids, statId)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
 // Scale formula ((ItemLevel * QualityModifier * ItemTypeModifier)^1.7095 * %ofStats) ^ (1/1.7095)) / StatModifier
 func scaleStat(itemLevel int, itemType int, itemQuality int, percOfStat float64, statModifier float64) int {
 	scaledUp := (math.Pow((float64(itemLevel)*config.QualityModifiers[itemQuality]*config.InvTypeModifiers[itemType]), 1.7095) * percOfStat)
@@ -958,7 +1001,7 @@ func scaleStatv2(scaleParams StatScaleParams) int {
 	modifier *= float64(scaleParams.NewItemLevel) / float64(scaleParams.ItemLevel)
 	scaledValue := float64(scaleParams.StatValue) * modifier // * config.InvTypeModifiers[scaleParams.ItemType]
 
-	log.Printf("------- scaledValue: %v modifier: %v", scaledValue, modifier)
+	slog.Debug("scaled stat value", logging.Stage, "stats", "stat", scaleParams.StatTypeId, "value", scaledValue, "modifier", modifier)
 	return int(math.Ceil(scaledValue))
 }
 
@@ -999,7 +1042,7 @@ func correctSpellAttackPower(item *Item, allStats map[int]*ItemStat) {
 	// do some manual corrections for stats oddly getting attack power and spell power
 	itemStats, err := item.GetStatList()
 	if err != nil {
-		log.Printf("Failed to get stat list: %v not attempting to fix stats", err)
+		item.logger("stats").Warn("failed to get stat list, not fixing attack and spell power", "err", err)
 	}
 	if slices.Contains(itemStats, STAT.AttackPower) && slices.Contains(itemStats, STAT.SpellPower) {
 
@@ -1028,6 +1071,9 @@ func correctSpellAttackPower(item *Item, allStats map[int]*ItemStat) {
 	}
 }
 
+// ClassUserTypes are the names of the class types GetClassUserType returns
+var ClassUserTypes = map[int]string{1: "strength", 2: "agility", 3: "ranged", 4: "caster", 5: "healer", 6: "tank", 7: "generic"}
+
 /**
  * This will determine the class type that would be the user of the item
  * Melee Strength Attacker: 1
@@ -1306,39 +1352,42 @@ func (item *Item) ApplyTierModifiers(optionalTier ...int) {
 	// }
 }
 
-func ItemToSql(item Item, reqLevel int, difficulty int) string {
-
-	fmt.Printf("-- Required level: %v\n", reqLevel)
+// GeneratedEntry is the item_template entry a generated copy of an item is written under, taken from the
+// range the current product line uses for the difficulty (see ids.Use)
+func GeneratedEntry(entry int, difficulty int) int {
+	return ids.Current().Item(difficulty).ID(entry)
+}
 
-	var name string = item.Name
+// AllocateSpellIds picks the ids the item's scaled spells are written under, call it once the spell values are final
+func (item *Item) AllocateSpellIds() error {
+	for i := range item.Spells {
+		if err := item.Spells[i].AllocateScaledId(*item.Quality); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	entryBump := 20000000
-	spellBump := 30000000
+func ItemToSql(item Item, reqLevel int, difficulty int) string {
 
-	if *item.Quality == 4 {
-		spellBump = 31000000
-	}
-	if *item.Quality == 5 {
-		spellBump = 32000000
-	}
+	fmt.Printf("-- Required level: %v\n", reqLevel)
 
-	if difficulty == 4 {
-		entryBump = 21000000
-	}
-	if difficulty == 5 {
-		entryBump = 22000000
+	spellList := ""
+	for _, spell := range item.Spells {
+		spellList += spells.SpellToSql(spell, *item.Quality)
 	}
 
-	name = getRandomWord(difficulty) + " " + name
+	statements := ItemStatements(item, reqLevel, difficulty)
+	return fmt.Sprintf("%s %s \n %s \n %s", spellList, statements[0], statements[1], strings.Join(statements[2:], "\n"))
+}
 
-	spellList := ""
-	if len(item.Spells) > 0 {
-		for i, spell := range item.Spells {
+// ItemStatements are the delete, copy and update of item_template that write the generated item,
+// the item's scaled spells are written separately with spells.SpellStatements
+func ItemStatements(item Item, reqLevel int, difficulty int) []string {
+	entryBump := GeneratedEntry(0, difficulty)
 
-			spellList += spells.SpellToSql(spell, *item.Quality)
-			item.UpdateField(fmt.Sprintf("SpellId%v", i), spellBump+spell.ID)
-		}
-	}
+	name, prefix := generatedName(item, difficulty)
+	item.UseSpellWriteIds()
 
 	delete := fmt.Sprintf("DELETE FROM acore_world.item_template WHERE entry = %v;", entryBump+item.Entry)
 
@@ -1390,86 +1439,136 @@ func ItemToSql(item Item, reqLevel int, difficulty int) string {
 	  WHERE src.entry = %v ON DUPLICATE KEY UPDATE entry = src.entry + %v;	  
 	`, entryBump, item.Entry, entryBump)
 
-	update := fmt.Sprintf(`
-	UPDATE acore_world.item_template
-	SET 
-	  Quality = %v,
-	  name = '%s',
-	  ItemLevel = %v,
-	  RequiredLevel = %v,
-	  dmg_min1 = %v,
-	  dmg_max1 = %v,
-	  dmg_min2 = %v,
-	  dmg_max2 = %v,
-	  StatsCount = %v,
-	  stat_type1 = %v,
-	  stat_value1 = %v,
-	  stat_type2 = %v,
-	  stat_value2 = %v,
-	  stat_type3 = %v,
-	  stat_value3 = %v,
-	  stat_type4 = %v,
-	  stat_value4 = %v,
-	  stat_type5 = %v,
-	  stat_value5 = %v,
-	  stat_type6 = %v,
-	  stat_value6 = %v,
-	  stat_type7 = %v,
-	  stat_value7 = %v,
-	  stat_type8 = %v,
-	  stat_value8 = %v,
-	  stat_type9 = %v,
-	  stat_value9 = %v,
-	  stat_type10 = %v,
-	  stat_value10 = %v,
-	  spellid_1 = %v,
-	  spellid_2 = %v,
-	  spellid_3 = %v,
-	  spelltrigger_1 = %v,
-	  spelltrigger_2 = %v,
-	  spelltrigger_3 = %v,
-	  socketColor_1 = %v,
-	  socketContent_1 = %v,
-	  socketColor_2 = %v,
-	  socketContent_2 = %v,
-	  socketColor_3 = %v,
-	  socketContent_3 = %v,
-	  socketBonus = %v,
-	  GemProperties = %v,
-	  RequiredDisenchantSkill = %v,
-	  DisenchantID = %v,
-	  SellPrice = FLOOR(100000 + (RAND() * 400001)),
-	  Armor = %v
-	WHERE entry = %v;
-	`, *item.Quality, strings.ReplaceAll(name, "'", "''"), *item.ItemLevel, reqLevel, *item.MinDmg1, *item.MaxDmg1, *item.MinDmg2, *item.MaxDmg2, *item.StatsCount,
-		*item.StatType1, *item.StatValue1, *item.StatType2, *item.StatValue2, *item.StatType3, *item.StatValue3, *item.StatType4, *item.StatValue4,
-		*item.StatType5, *item.StatValue5, *item.StatType6, *item.StatValue6, *item.StatType7, *item.StatValue7, *item.StatType8, *item.StatValue8,
-		*item.StatType9, *item.StatValue9, *item.StatType10, *item.StatValue10, *item.SpellId1, *item.SpellId2, *item.SpellId3, *item.SpellTrigger1, *item.SpellTrigger2,
-		*item.SpellTrigger3, *item.SocketColor1, *item.SocketContent1, *item.SocketColor2, *item.SocketContent2,
-		*item.SocketColor3, *item.SocketContent3, *item.SocketBonus, *item.GemProperties,
-		375, 68, *item.Armor, entryBump+item.Entry)
-
-	return fmt.Sprintf("%s %s \n %s \n %s", spellList, delete, clone, update)
+	changes := itemChanges(item, name, reqLevel)
+	sets := make([]string, len(changes))
+	for i, change := range changes {
+		sets[i] = fmt.Sprintf("\t  %s = %s", change.column, sqlrow.Literal(change.value))
+	}
+	update := fmt.Sprintf("\n\tUPDATE acore_world.item_template\n\tSET \n%s\n\tWHERE entry = %v;\n\t", strings.Join(sets, ",\n"), entryBump+item.Entry)
+
+	statements := []string{delete, clone, update}
+	return append(statements, ItemLocaleStatements(entryBump+item.Entry, item.Entry, prefix)...)
 }
 
-func getRandomWord(difficulty int) string {
-	mythic := []string{"Mythic", "Powerful", "Stalwart", "Venerated", "Mighty", "Unyielding"}
-	legendary := []string{"Legendary", "Fabled", "Exalted", "Magnificent", "Pristine", "Supreme", "Glorious"}
-	ascendant := []string{"Ascendant", "Godlike", "Celestial", "Transcendant", "Divine", "Omnipotent", "Demonforged", "Immortal", "Omniscient", "Ethereal"}
-
-	r := rand.New(rand.NewPCG(uint64(time.Now().UnixNano()), 2))
-
-	switch difficulty {
-	case 3: // Mythic
-		randomIndex := r.IntN(len(mythic))
-		return mythic[randomIndex]
-	case 4: // Legendary
-		randomIndex := r.IntN(len(legendary))
-		return legendary[randomIndex]
-	case 5: // Ascendant
-		randomIndex := r.IntN(len(ascendant))
-		return ascendant[randomIndex]
-	default:
+type change struct {
+	column string
+	value  interface{}
+}
+
+// itemChanges are the item_template columns a generated item sets on top of its source row
+func itemChanges(item Item, name string, reqLevel int) []change {
+	return []change{
+		{"Quality", *item.Quality},
+		{"name", name},
+		{"ItemLevel", *item.ItemLevel},
+		{"RequiredLevel", reqLevel},
+		{"dmg_min1", *item.MinDmg1},
+		{"dmg_max1", *item.MaxDmg1},
+		{"dmg_min2", *item.MinDmg2},
+		{"dmg_max2", *item.MaxDmg2},
+		{"StatsCount", *item.StatsCount},
+		{"stat_type1", *item.StatType1},
+		{"stat_value1", *item.StatValue1},
+		{"stat_type2", *item.StatType2},
+		{"stat_value2", *item.StatValue2},
+		{"stat_type3", *item.StatType3},
+		{"stat_value3", *item.StatValue3},
+		{"stat_type4", *item.StatType4},
+		{"stat_value4", *item.StatValue4},
+		{"stat_type5", *item.StatType5},
+		{"stat_value5", *item.StatValue5},
+		{"stat_type6", *item.StatType6},
+		{"stat_value6", *item.StatValue6},
+		{"stat_type7", *item.StatType7},
+		{"stat_value7", *item.StatValue7},
+		{"stat_type8", *item.StatType8},
+		{"stat_value8", *item.StatValue8},
+		{"stat_type9", *item.StatType9},
+		{"stat_value9", *item.StatValue9},
+		{"stat_type10", *item.StatType10},
+		{"stat_value10", *item.StatValue10},
+		{"spellid_1", *item.SpellId1},
+		{"spellid_2", *item.SpellId2},
+		{"spellid_3", *item.SpellId3},
+		{"spelltrigger_1", *item.SpellTrigger1},
+		{"spelltrigger_2", *item.SpellTrigger2},
+		{"spelltrigger_3", *item.SpellTrigger3},
+		{"socketColor_1", *item.SocketColor1},
+		{"socketContent_1", *item.SocketContent1},
+		{"socketColor_2", *item.SocketColor2},
+		{"socketContent_2", *item.SocketContent2},
+		{"socketColor_3", *item.SocketColor3},
+		{"socketContent_3", *item.SocketContent3},
+		{"socketBonus", *item.SocketBonus},
+		{"GemProperties", *item.GemProperties},
+		{"RequiredDisenchantSkill", 375},
+		{"DisenchantID", 68},
+		{"SellPrice", SellPrice()},
+		{"Armor", *item.Armor},
+	}
+}
+
+// ItemRow is the generated item as a complete item_template row, the source row with the generated
+// entry and changes on top so the sql does not need the source row to be there when it runs.
+// The item_template_locale rows of the generated item come with it, see ItemLocaleRows.
+func ItemRow(db sqlrow.Querier, item Item, reqLevel int, difficulty int) (sqlrow.Row, []sqlrow.Row, error) {
+	name, prefix := generatedName(item, difficulty)
+	item.UseSpellWriteIds()
+
+	row, found, err := sqlrow.Read(db, "item_template", "entry", item.Entry)
+	if err != nil {
+		return sqlrow.Row{}, nil, err
+	}
+	if !found {
+		return sqlrow.Row{}, nil, fmt.Errorf("source item %d (%s) is not in item_template", item.Entry, item.Name)
+	}
+
+	if err := row.Set("entry", GeneratedEntry(item.Entry, difficulty)); err != nil {
+		return sqlrow.Row{}, nil, err
+	}
+	for _, change := range itemChanges(item, name, reqLevel) {
+		if err := row.Set(change.column, change.value); err != nil {
+			return sqlrow.Row{}, nil, err
+		}
+	}
+
+	locales, err := ItemLocaleRows(db, GeneratedEntry(item.Entry, difficulty), item.Entry, item.Name, prefix)
+	if err != nil {
+		return sqlrow.Row{}, nil, err
+	}
+	return row, locales, nil
+}
+
+// SellPrice is between 10 and 50 gold, picked here instead of with RAND() in the sql so a seeded run writes the same price
+func SellPrice() int {
+	return 100000 + rng.IntN(400001)
+}
+
+// GeneratedName is the name the current namer picks for the generated copy of item, see naming.Namer
+func GeneratedName(item Item, difficulty int) string {
+	name, _ := generatedName(item, difficulty)
+	return name
+}
+
+func generatedName(item Item, difficulty int) (string, Prefix) {
+	name := naming.Current().Name(naming.Item{
+		Entry:      GeneratedEntry(item.Entry, difficulty),
+		Name:       item.Name,
+		Difficulty: difficulty,
+		Map:        item.Map,
+		Role:       item.Role(),
+	})
+	return name.Text, prefixOf(difficulty, name.Prefix)
+}
+
+// Role is the naming role of the item's class user type, empty when the item is missing the fields to tell
+func (item *Item) Role() string {
+	if item.Class == nil || item.Subclass == nil || item.InventoryType == nil {
+		return ""
+	}
+	classType := item.GetClassUserType()
+	if classType < 0 || classType >= len(naming.Roles) {
 		return ""
 	}
+	return naming.Roles[classType]
 }
@@ -0,0 +1,116 @@
+package items
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlrow"
+	"github.com/araxiaonline/endgame-item-generator/internal/locale"
+)
+
+// Prefix is the difficulty word of a generated name, Index is its place in the word list of every locale
+type Prefix struct {
+	Difficulty int
+	Index      int
+}
+
+// prefixOf finds word in the English list, a word from a -names file that is not in it gets no translation
+func prefixOf(difficulty int, word string) Prefix {
+	for i, english := range locale.English.For(difficulty) {
+		if english == word {
+			return Prefix{Difficulty: difficulty, Index: i}
+		}
+	}
+	return Prefix{Difficulty: difficulty, Index: -1}
+}
+
+// Word is the English word, empty for difficulties without one
+func (p Prefix) Word() string {
+	words := locale.English.For(p.Difficulty)
+	if p.Index < 0 || p.Index >= len(words) {
+		return ""
+	}
+	return words[p.Index]
+}
+
+// localized is name with the locale's word in front, name alone when the locale has no word
+func (p Prefix) localized(code, name string) string {
+	word := locale.Current().Word(code, p.Difficulty, p.Index)
+	if word == "" {
+		return name
+	}
+	return word + " " + name
+}
+
+// ItemLocaleStatements write an item_template_locale row for every locale in use. The name is the
+// source item's translated name, or its English one when it has none, with the locale's word in front.
+func ItemLocaleStatements(entry, source int, prefix Prefix) []string {
+	codes := locale.Current().Locales()
+	if len(codes) == 0 {
+		return nil
+	}
+
+	words := make([]string, len(codes))
+	for i, code := range codes {
+		word := locale.Current().Word(code, prefix.Difficulty, prefix.Index)
+		if word != "" {
+			word += " "
+		}
+		words[i] = fmt.Sprintf("SELECT %s AS locale, %s AS word", sqlrow.Quote(code), sqlrow.Quote(word))
+	}
+
+	delete := fmt.Sprintf("DELETE FROM acore_world.item_template_locale WHERE ID = %v;", entry)
+	insert := fmt.Sprintf(`
+	INSERT INTO acore_world.item_template_locale (ID, locale, Name, Description, VerifiedBuild)
+	SELECT %v, words.locale, CONCAT(words.word, COALESCE(src.Name, item.name)), COALESCE(src.Description, ''), 0
+	FROM (%s) AS words
+	JOIN acore_world.item_template AS item ON item.entry = %v
+	LEFT JOIN acore_world.item_template_locale AS src ON src.ID = %v AND src.locale = words.locale;
+	`, entry, strings.Join(words, " UNION ALL "), source, source)
+
+	return []string{delete, insert}
+}
+
+// ItemLocaleRows are the rows ItemLocaleStatements write, read up front for the full row output
+func ItemLocaleRows(db sqlrow.Querier, entry, source int, name string, prefix Prefix) ([]sqlrow.Row, error) {
+	codes := locale.Current().Locales()
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	rows, err := db.Queryx("SELECT locale, Name, Description FROM item_template_locale WHERE ID = ?", source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the translations of item %d: %w", source, err)
+	}
+	defer rows.Close()
+
+	translated := map[string][2]sql.NullString{}
+	for rows.Next() {
+		var code string
+		var text [2]sql.NullString
+		if err := rows.Scan(&code, &text[0], &text[1]); err != nil {
+			return nil, fmt.Errorf("failed to read the translations of item %d: %w", source, err)
+		}
+		translated[code] = text
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	list := make([]sqlrow.Row, len(codes))
+	for i, code := range codes {
+		localName, description := name, ""
+		if text, ok := translated[code]; ok {
+			if text[0].Valid {
+				localName = text[0].String
+			}
+			description = text[1].String
+		}
+		list[i] = sqlrow.Row{
+			Columns: []string{"ID", "locale", "Name", "Description", "VerifiedBuild"},
+			Values:  []interface{}{entry, code, prefix.localized(code, localName), description, 0},
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,175 @@
+package items
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/dbtest"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlrow"
+	"github.com/araxiaonline/endgame-item-generator/internal/locale"
+	"github.com/araxiaonline/endgame-item-generator/internal/rng"
+	"github.com/araxiaonline/endgame-item-generator/internal/spells"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// item_template with a column for every DbItem field and one source row where every value is 0
+func newRowTestDb(t *testing.T) (*sqlx.DB, mysql.DbItem) {
+	t.Helper()
+
+	db, err := sqlx.Open("sqlite3", t.TempDir()+"/world.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	item := mysql.DbItem{}
+	value := reflect.ValueOf(&item).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if field := value.Field(i); field.Kind() == reflect.Ptr {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+	}
+	item.Entry = 100
+	item.Name = "Sword"
+	*item.Quality = 4
+
+	// plus the columns a generated item sets that DbItem does not read
+	extra := []string{}
+	for _, change := range itemChanges(ItemFromDbItem(item), "", 0) {
+		extra = append(extra, change.column)
+	}
+
+	db.MustExec(dbtest.TableSql("item_template", mysql.DbItem{}, extra...))
+	db.MustExec("INSERT INTO item_template (entry, name, Quality) VALUES (100, 'Sword', 4)")
+	db.MustExec("CREATE TABLE item_template_locale (ID, locale, Name, Description, VerifiedBuild)")
+	db.MustExec("INSERT INTO item_template_locale VALUES (100, 'frFR', 'Épée', 'Tranchante', 1)")
+	return db, item
+}
+
+func TestItemRowMatchesStatements(t *testing.T) {
+	db, dbItem := newRowTestDb(t)
+	item := ItemFromDbItem(dbItem)
+
+	rng.Seed(99)
+	row, _, err := ItemRow(db, item, 80, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng.Seed(99)
+	statements := ItemStatements(item, 80, 4)
+
+	// the full row carries the same values the update writes over the copied source row
+	for _, check := range []string{"entry", "name", "RequiredLevel", "SellPrice", "DisenchantID"} {
+		value, ok := row.Get(check)
+		if !ok {
+			t.Fatalf("row has no %s", check)
+		}
+		if check == "entry" {
+			if value != 21000100 {
+				t.Errorf("expected entry 21000100, got %v", value)
+			}
+			continue
+		}
+		if !strings.Contains(statements[2], fmt.Sprintf("%s = %s", check, literalOf(value))) {
+			t.Errorf("update does not set %s to %v:\n%s", check, value, statements[2])
+		}
+	}
+}
+
+func TestGeneratedSpellSlots(t *testing.T) {
+	db, dbItem := newRowTestDb(t)
+	item := ItemFromDbItem(dbItem)
+	item.Spells = []spells.Spell{
+		{DbSpell: mysql.DbSpell{ID: 7597}, ItemSpellSlot: 1, ScaledId: 34000001},
+		{DbSpell: mysql.DbSpell{ID: 9331}, ItemSpellSlot: 3},
+	}
+
+	update := ItemStatements(item, 80, 4)[2]
+	for _, expected := range []string{
+		fmt.Sprintf("spellid_1 = %d", item.Spells[0].WriteId(4)),
+		"spellid_2 = 0",
+		fmt.Sprintf("spellid_3 = %d", item.Spells[1].WriteId(4)),
+	} {
+		if !strings.Contains(update, expected) {
+			t.Errorf("update does not set %s:\n%s", expected, update)
+		}
+	}
+	row, _, err := ItemRow(db, item, 80, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for slot, expected := range map[string]int{"spellid_1": item.Spells[0].WriteId(4), "spellid_2": 0, "spellid_3": item.Spells[1].WriteId(4)} {
+		if value, _ := row.Get(slot); value != expected {
+			t.Errorf("row has %s %v, expected %d", slot, value, expected)
+		}
+	}
+
+	// the item handed in keeps the source ids
+	if *item.SpellId1 != 0 {
+		t.Errorf("ItemStatements changed the item's spellid_1 to %d", *item.SpellId1)
+	}
+}
+
+func TestItemLocaleRows(t *testing.T) {
+	db, dbItem := newRowTestDb(t)
+
+	rng.Seed(99)
+	row, localeRows, err := ItemRow(db, ItemFromDbItem(dbItem), 80, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, _ := row.Get("name")
+	index := -1
+	for i, word := range locale.English.Legendary {
+		if name == word+" Sword" {
+			index = i
+		}
+	}
+	if index < 0 {
+		t.Fatalf("unexpected name %v", name)
+	}
+
+	// one row per locale, translated source names are used and the others fall back to the English one
+	words := locale.DefaultWords()
+	expected := map[string][2]string{
+		"deDE": {words["deDE"].Legendary[index] + " Sword", ""},
+		"frFR": {words["frFR"].Legendary[index] + " Épée", "Tranchante"},
+		"ruRU": {words["ruRU"].Legendary[index] + " Sword", ""},
+	}
+	if len(localeRows) != len(expected) {
+		t.Fatalf("expected %d locale rows, got %d", len(expected), len(localeRows))
+	}
+	for _, localeRow := range localeRows {
+		code, _ := localeRow.Get("locale")
+		localName, _ := localeRow.Get("Name")
+		description, _ := localeRow.Get("Description")
+		id, _ := localeRow.Get("ID")
+		want := expected[code.(string)]
+		if id != 21000100 || localName != want[0] || description != want[1] {
+			t.Errorf("%v: expected %v, got %v %v %v", code, want, id, localName, description)
+		}
+	}
+
+	rng.Seed(99)
+	statements := ItemStatements(ItemFromDbItem(dbItem), 80, 4)
+	if len(statements) != 5 || !strings.Contains(statements[4], "'frFR' AS locale, "+sqlrow.Quote(words["frFR"].Legendary[index]+" ")) {
+		t.Errorf("expected the locale statements to use the same words:\n%s", strings.Join(statements[3:], "\n"))
+	}
+
+	locale.Use(locale.Words{})
+	defer locale.Use(locale.DefaultWords())
+	if statements := ItemStatements(ItemFromDbItem(dbItem), 80, 4); len(statements) != 3 {
+		t.Errorf("expected no locale statements without word lists, got %d statements", len(statements))
+	}
+}
+
+func literalOf(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return "'" + s + "'"
+	}
+	return fmt.Sprint(value)
+}
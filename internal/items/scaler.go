@@ -0,0 +1,85 @@
+package items
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/config"
+)
+
+// StatScaler is a formula for the new value of one stat when an item is scaled to a new level
+type StatScaler interface {
+	ScaleStat(params StatScaleParams) int
+}
+
+// StatScalerFunc lets a plain function be registered as a StatScaler
+type StatScalerFunc func(params StatScaleParams) int
+
+func (f StatScalerFunc) ScaleStat(params StatScaleParams) int {
+	return f(params)
+}
+
+// DefaultScaler is the formula ScaleItem uses unless UseScaler picks another one
+const DefaultScaler = "v3"
+
+var scalers = map[string]StatScaler{
+	"v1": StatScalerFunc(func(p StatScaleParams) int {
+		return scaleStat(p.NewItemLevel, p.ItemType, p.Quality, p.Percent, config.StatModifiers[p.StatTypeId])
+	}),
+	"v2":     StatScalerFunc(scaleStatv2),
+	"v3":     StatScalerFunc(func(p StatScaleParams) int { return scaleStatv3(p, p.Difficulty) }),
+	"budget": StatScalerFunc(scaleStatBudget),
+}
+
+var currentScaler = scalers[DefaultScaler]
+
+// RegisterScaler adds a formula that can be picked by name with UseScaler
+func RegisterScaler(name string, scaler StatScaler) {
+	scalers[name] = scaler
+}
+
+// UseScaler sets the formula ScaleItem uses for every stat
+func UseScaler(name string) error {
+	scaler, err := GetScaler(name)
+	if err != nil {
+		return err
+	}
+	currentScaler = scaler
+	return nil
+}
+
+func GetScaler(name string) (StatScaler, error) {
+	scaler, ok := scalers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown stat scaler %q, expected one of %s", name, strings.Join(ScalerNames(), ", "))
+	}
+	return scaler, nil
+}
+
+func ScalerNames() []string {
+	names := make([]string, 0, len(scalers))
+	for name := range scalers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Budget formula, the new item gets a stat budget of ItemLevel * QualityModifier * InvTypeModifier
+// and each stat keeps the share of the budget it had on the original item.
+// i.e)  Epic chest at 300 with 40% of its budget in stamina  300 * 1.5 * 1.0 * 0.40 * 1.0 = 180 Stamina
+func scaleStatBudget(scaleParams StatScaleParams) int {
+	budget := float64(scaleParams.NewItemLevel) *
+		config.QualityModifiers[scaleParams.Quality] *
+		config.InvTypeModifiers[scaleParams.ItemType]
+
+	// percents are of the budget adjusted by the stat modifier, see GetStatPercents
+	statModifier, ok := config.StatModifiers[scaleParams.StatTypeId]
+	if !ok {
+		statModifier = 1.0
+	}
+
+	return int(math.Ceil(budget * scaleParams.Percent * statModifier))
+}
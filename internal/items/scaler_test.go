@@ -0,0 +1,67 @@
+package items
+
+import (
+	"testing"
+)
+
+func TestScalers(t *testing.T) {
+	params := StatScaleParams{
+		ItemLevel:    200,
+		NewItemLevel: 300,
+		Quality:      4,
+		ItemType:     5,
+		StatTypeId:   7,
+		StatValue:    100,
+		Percent:      0.4,
+		Difficulty:   3,
+	}
+
+	tests := []struct {
+		name     string
+		expected int
+	}{
+		{"v1", scaleStat(300, 5, 4, 0.4, 1.0)},
+		{"v2", scaleStatv2(params)},
+		{"v3", scaleStatv3(params, 3)},
+		// 300 * 1.5 (epic) * 1.0 (chest) * 0.4 * 1.0 (stamina)
+		{"budget", 180},
+	}
+
+	for _, tt := range tests {
+		scaler, err := GetScaler(tt.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := scaler.ScaleStat(params); got != tt.expected {
+			t.Errorf("%s ScaleStat() = %d, want %d", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestBudgetScalerUsesSlot(t *testing.T) {
+	chest := StatScaleParams{NewItemLevel: 300, Quality: 4, ItemType: 5, StatTypeId: 7, Percent: 0.5}
+	wrists := chest
+	wrists.ItemType = 9
+
+	if scaleStatBudget(wrists) >= scaleStatBudget(chest) {
+		t.Errorf("wrists got %d stamina, chest %d, wrists should get less", scaleStatBudget(wrists), scaleStatBudget(chest))
+	}
+}
+
+func TestUseScaler(t *testing.T) {
+	defer UseScaler(DefaultScaler)
+
+	if err := UseScaler("v4"); err == nil {
+		t.Error("expected an error for an unknown scaler")
+	}
+
+	RegisterScaler("flat", StatScalerFunc(func(p StatScaleParams) int { return 42 }))
+	defer delete(scalers, "flat")
+
+	if err := UseScaler("flat"); err != nil {
+		t.Fatal(err)
+	}
+	if got := currentScaler.ScaleStat(StatScaleParams{}); got != 42 {
+		t.Errorf("current scaler returned %d, want 42", got)
+	}
+}
@@ -0,0 +1,50 @@
+package items
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/rng"
+)
+
+func TestSeededPrices(t *testing.T) {
+	draw := func() []interface{} {
+		rng.Seed(1234)
+		return []interface{}{SellPrice(), SellPrice(), SellPrice()}
+	}
+
+	first, second := draw(), draw()
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("same seed gave %v then %v", first, second)
+	}
+}
+
+func TestAddStatsOrder(t *testing.T) {
+	stats := map[int]*ItemStat{
+		45: {Value: 40},
+		7:  {Value: 30},
+		32: {Value: 20},
+		5:  {Value: 10},
+	}
+
+	for run := 0; run < 10; run++ {
+		item := Item{DbItem: mysql.DbItem{}}
+		fields := []**int{
+			&item.StatType1, &item.StatValue1, &item.StatType2, &item.StatValue2, &item.StatType3, &item.StatValue3,
+			&item.StatType4, &item.StatValue4, &item.StatType5, &item.StatValue5, &item.StatType6, &item.StatValue6,
+			&item.StatType7, &item.StatValue7, &item.StatType8, &item.StatValue8, &item.StatType9, &item.StatValue9,
+			&item.StatType10, &item.StatValue10,
+		}
+		for _, field := range fields {
+			*field = ptrInt(0)
+		}
+
+		item.addStats(stats)
+
+		got := []int{*item.StatType1, *item.StatType2, *item.StatType3, *item.StatType4}
+		if want := []int{5, 7, 32, 45}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("stat slots = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package items
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/ids"
+	"github.com/araxiaonline/endgame-item-generator/internal/spells"
+)
+
+// an item with every nullable column set to 0 so the statements can be built from it
+func zeroItem(entry int, quality int) Item {
+	dbItem := mysql.DbItem{Entry: entry, Name: "Test Blade"}
+	v := reflect.ValueOf(&dbItem).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if field := v.Field(i); field.Kind() == reflect.Ptr && field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+	}
+	*dbItem.Quality = quality
+	return ItemFromDbItem(dbItem)
+}
+
+func TestSpellSlotsUseVariantIds(t *testing.T) {
+	m, err := ids.LoadMap(filepath.Join(t.TempDir(), "spell-ids.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spells.UseIdMap(m)
+	defer spells.UseIdMap(nil)
+
+	proc := func(slot int, id int, points int) spells.Spell {
+		return spells.Spell{DbSpell: mysql.DbSpell{ID: id, EffectBasePoints1: points}, ItemSpellSlot: slot}
+	}
+
+	// the first item takes the quality ids, the second scales the same spells to other values
+	first := zeroItem(100, 4)
+	first.Spells = []spells.Spell{proc(1, 7597, 20), proc(3, 9331, 40)}
+	second := zeroItem(101, 4)
+	second.Spells = []spells.Spell{proc(1, 7597, 30), proc(2, 9331, 40), proc(3, 21919, 120)}
+
+	tests := []struct {
+		name     string
+		item     Item
+		expected [3]int
+	}{
+		{"quality ids", first, [3]int{31007597, 0, 31009331}},
+		{"variant ids and a shared row", second, [3]int{34000000, 31009331, 31021919}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			item := test.item
+			if err := item.AllocateSpellIds(); err != nil {
+				t.Fatal(err)
+			}
+
+			update := ItemStatements(item, 80, 4)[2]
+			for i, expected := range test.expected {
+				column := fmt.Sprintf("spellid_%d = %d,", i+1, expected)
+				if !strings.Contains(update, column) {
+					t.Errorf("expected the update to set %s\n%s", column, update)
+				}
+			}
+
+			item.UseSpellWriteIds()
+			got := [3]int{*item.SpellId1, *item.SpellId2, *item.SpellId3}
+			if got != test.expected {
+				t.Errorf("expected spell slots %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
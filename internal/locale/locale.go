@@ -0,0 +1,148 @@
+package locale
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Codes are the item_template_locale locales the server reads, enUS is item_template itself
+var Codes = []string{"koKR", "frFR", "deDE", "zhCN", "zhTW", "esES", "esMX", "ruRU"}
+
+// Prefixes are the words a generated name starts with per difficulty. Every locale lists them in the
+// same order as English so one pick gives the same word in every language.
+type Prefixes struct {
+	Mythic    []string `json:"mythic"`
+	Legendary []string `json:"legendary"`
+	Ascendant []string `json:"ascendant"`
+}
+
+// For is the word list of difficulty 3-5, nil for the others which get no prefix
+func (p Prefixes) For(difficulty int) []string {
+	switch difficulty {
+	case 3:
+		return p.Mythic
+	case 4:
+		return p.Legendary
+	case 5:
+		return p.Ascendant
+	default:
+		return nil
+	}
+}
+
+// English are the prefixes of item_template.name
+var English = Prefixes{
+	Mythic:    []string{"Mythic", "Powerful", "Stalwart", "Venerated", "Mighty", "Unyielding"},
+	Legendary: []string{"Legendary", "Fabled", "Exalted", "Magnificent", "Pristine", "Supreme", "Glorious"},
+	Ascendant: []string{"Ascendant", "Godlike", "Celestial", "Transcendant", "Divine", "Omnipotent", "Demonforged", "Immortal", "Omniscient", "Ethereal"},
+}
+
+// Words are the prefixes of every locale generated items get an item_template_locale row for
+type Words map[string]Prefixes
+
+var defaultWords = Words{
+	"deDE": {
+		Mythic:    []string{"Mythisch", "Mächtig", "Standhaft", "Verehrt", "Gewaltig", "Unnachgiebig"},
+		Legendary: []string{"Legendär", "Sagenhaft", "Erhaben", "Prächtig", "Makellos", "Überragend", "Glorreich"},
+		Ascendant: []string{"Aufgestiegen", "Göttergleich", "Himmlisch", "Transzendent", "Göttlich", "Allmächtig", "Dämonengeschmiedet", "Unsterblich", "Allwissend", "Ätherisch"},
+	},
+	"frFR": {
+		Mythic:    []string{"Mythique", "Puissant", "Vaillant", "Vénéré", "Formidable", "Inflexible"},
+		Legendary: []string{"Légendaire", "Fabuleux", "Exalté", "Magnifique", "Immaculé", "Suprême", "Glorieux"},
+		Ascendant: []string{"Ascendant", "Déifié", "Céleste", "Transcendant", "Divin", "Omnipotent", "Forgé-démon", "Immortel", "Omniscient", "Éthéré"},
+	},
+	"ruRU": {
+		Mythic:    []string{"Мифический", "Мощный", "Стойкий", "Почитаемый", "Могучий", "Непреклонный"},
+		Legendary: []string{"Легендарный", "Сказочный", "Превознесенный", "Великолепный", "Безупречный", "Верховный", "Славный"},
+		Ascendant: []string{"Вознесенный", "Богоподобный", "Небесный", "Трансцендентный", "Божественный", "Всемогущий", "Демонический", "Бессмертный", "Всеведущий", "Эфирный"},
+	},
+}
+
+// DefaultWords is the built in word lists the tools use without -locales
+func DefaultWords() Words {
+	return defaultWords
+}
+
+// Load reads and validates the word lists at path, an empty path is the built in lists
+func Load(path string) (Words, error) {
+	if path == "" {
+		return defaultWords, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read locales %s: %w", path, err)
+	}
+	words := Words{}
+	if err := json.Unmarshal(data, &words); err != nil {
+		return nil, fmt.Errorf("failed to parse locales %s: %w", path, err)
+	}
+	if err := words.Validate(); err != nil {
+		return nil, fmt.Errorf("locales %s: %w", path, err)
+	}
+	return words, nil
+}
+
+// Validate checks every locale is one the server reads and has as many words as English for each difficulty
+func (w Words) Validate() error {
+	for _, code := range w.Locales() {
+		if !known(code) {
+			return fmt.Errorf("unknown locale %s, use one of %v", code, Codes)
+		}
+		for difficulty := 3; difficulty <= 5; difficulty++ {
+			if got, want := len(w[code].For(difficulty)), len(English.For(difficulty)); got != want {
+				return fmt.Errorf("%s has %d words for difficulty %d, English has %d", code, got, difficulty, want)
+			}
+		}
+	}
+	return nil
+}
+
+// Locales in a fixed order so the sql comes out the same every run
+func (w Words) Locales() []string {
+	codes := make([]string, 0, len(w))
+	for code := range w {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Word is the locale's word at index of the difficulty's list, empty if there is none
+func (w Words) Word(code string, difficulty, index int) string {
+	list := w[code].For(difficulty)
+	if index < 0 || index >= len(list) {
+		return ""
+	}
+	return list[index]
+}
+
+func (w Words) String() string {
+	out, err := json.MarshalIndent(w, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("failed to print locales: %v", err)
+	}
+	return string(out)
+}
+
+func known(code string) bool {
+	for _, c := range Codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+var current = defaultWords
+
+// Use makes words the lists the generated names are translated with, empty words turns the translations off
+func Use(words Words) {
+	current = words
+}
+
+func Current() Words {
+	return current
+}
@@ -0,0 +1,58 @@
+package locale
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDefaultWordsFile(t *testing.T) {
+	words, err := Load("../../locales/default.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(words, DefaultWords()) {
+		t.Error("locales/default.json is out of date with the built in word lists")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := DefaultWords()["frFR"]
+	short := Prefixes{Mythic: valid.Mythic[:2], Legendary: valid.Legendary, Ascendant: valid.Ascendant}
+
+	tests := []struct {
+		name  string
+		words Words
+		valid bool
+	}{
+		{"default", DefaultWords(), true},
+		{"none", Words{}, true},
+		{"spanish", Words{"esES": valid}, true},
+		{"unknown locale", Words{"xxXX": valid}, false},
+		{"english is not a locale row", Words{"enUS": valid}, false},
+		{"missing words", Words{"frFR": short}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.words.Validate()
+			if test.valid && err != nil {
+				t.Errorf("expected valid, got %v", err)
+			}
+			if !test.valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestWord(t *testing.T) {
+	words := DefaultWords()
+	if word := words.Word("frFR", 4, 0); word != "Légendaire" {
+		t.Errorf("expected Légendaire, got %q", word)
+	}
+	if word := words.Word("frFR", 2, 0); word != "" {
+		t.Errorf("expected no word below mythic, got %q", word)
+	}
+	if word := words.Word("frFR", 3, 6); word != "" {
+		t.Errorf("expected no word past the end of the list, got %q", word)
+	}
+}
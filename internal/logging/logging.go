@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Attribute keys, every package names the item and spell it is working on the same way so a json log
+// can be filtered by them
+const (
+	Entry      = "entry"
+	Spell      = "spell"
+	Difficulty = "difficulty"
+	Stage      = "stage"
+)
+
+// Off is above every level, nothing is logged
+const Off = slog.Level(100)
+
+var Levels = []string{"debug", "info", "warn", "error", "off"}
+
+var Formats = []string{"text", "json"}
+
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	case "off":
+		return Off, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q, use one of %s", name, strings.Join(Levels, ", "))
+}
+
+// NewHandler writes records of level and above to w as text or json. Debug records carry the file and
+// line they came from.
+func NewHandler(w io.Writer, format string, level slog.Level) (slog.Handler, error) {
+	options := &slog.HandlerOptions{Level: level, AddSource: level <= slog.LevelDebug}
+	switch format {
+	case "text":
+		return slog.NewTextHandler(w, options), nil
+	case "json":
+		return slog.NewJSONHandler(w, options), nil
+	}
+	return nil, fmt.Errorf("unknown log format %q, use one of %s", format, strings.Join(Formats, ", "))
+}
+
+// Setup makes the default slog logger write to path, or stderr when path is empty, so the logs never
+// end up in sql printed to stdout. The log package goes through the same logger at the info level.
+// The returned close func closes the log file.
+func Setup(level, format, path string) (func() error, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	var w io.Writer = os.Stderr
+	close := func() error { return nil }
+	if lvl == Off {
+		w = io.Discard
+	} else if path != "" {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file %s: %w", path, err)
+		}
+		w, close = file, file.Close
+	}
+
+	handler, err := NewHandler(w, format, lvl)
+	if err != nil {
+		close()
+		return nil, err
+	}
+	slog.SetDefault(slog.New(handler))
+	// slog adds its own time, the file and line come from AddSource
+	log.SetFlags(0)
+	return close, nil
+}
@@ -0,0 +1,86 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name  string
+		level slog.Level
+		valid bool
+	}{
+		{"debug", slog.LevelDebug, true},
+		{"WARN", slog.LevelWarn, true},
+		{"off", Off, true},
+		{"loud", 0, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			level, err := ParseLevel(test.name)
+			if test.valid && (err != nil || level != test.level) {
+				t.Errorf("expected %v, got %v %v", test.level, level, err)
+			}
+			if !test.valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestJsonHandler(t *testing.T) {
+	var out bytes.Buffer
+	handler, err := NewHandler(&out, "json", slog.LevelWarn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := slog.New(handler).With(Entry, 19019, Difficulty, 3)
+	logger.Debug("left out")
+	logger.Warn("failed to get the spell", Spell, 7597, Stage, "spells")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected only the warning, got:\n%s", out.String())
+	}
+	record := map[string]any{}
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatal(err)
+	}
+	for key, value := range map[string]any{Entry: 19019.0, Difficulty: 3.0, Spell: 7597.0, Stage: "spells", "msg": "failed to get the spell"} {
+		if record[key] != value {
+			t.Errorf("expected %s to be %v, got %v", key, value, record[key])
+		}
+	}
+
+	if _, err := NewHandler(&out, "xml", slog.LevelInfo); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestSetupFile(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	path := filepath.Join(t.TempDir(), "run.log")
+	close, err := Setup("info", "text", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slog.Info("scaling item", Entry, 19019)
+	if err := close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "msg=\"scaling item\" entry=19019") {
+		t.Errorf("unexpected log file:\n%s", data)
+	}
+}
@@ -0,0 +1,71 @@
+package mpq
+
+// Storm's encryption table, every hash and the hash/block table encryption are keyed off of it
+var cryptTable [0x500]uint32
+
+const (
+	hashTableOffset = 0
+	hashNameA       = 1
+	hashNameB       = 2
+	hashFileKey     = 3
+)
+
+func init() {
+	seed := uint32(0x00100001)
+	for index1 := 0; index1 < 0x100; index1++ {
+		index2 := index1
+		for i := 0; i < 5; i++ {
+			seed = (seed*125 + 3) % 0x2AAAAB
+			temp1 := (seed & 0xFFFF) << 0x10
+			seed = (seed*125 + 3) % 0x2AAAAB
+			temp2 := seed & 0xFFFF
+			cryptTable[index2] = temp1 | temp2
+			index2 += 0x100
+		}
+	}
+}
+
+// hashString hashes an archive path, paths are case insensitive and always use backslashes
+func hashString(s string, hashType uint32) uint32 {
+	seed1 := uint32(0x7FED7FED)
+	seed2 := uint32(0xEEEEEEEE)
+
+	for i := 0; i < len(s); i++ {
+		ch := uint32(normalizeChar(s[i]))
+		seed1 = cryptTable[hashType*0x100+ch] ^ (seed1 + seed2)
+		seed2 = ch + seed1 + seed2 + (seed2 << 5) + 3
+	}
+
+	return seed1
+}
+
+func normalizeChar(c byte) byte {
+	if c >= 'a' && c <= 'z' {
+		return c - 'a' + 'A'
+	}
+	if c == '/' {
+		return '\\'
+	}
+	return c
+}
+
+func encrypt(data []uint32, key uint32) {
+	seed := uint32(0xEEEEEEEE)
+	for i, plain := range data {
+		seed += cryptTable[0x400+(key&0xFF)]
+		data[i] = plain ^ (key + seed)
+		key = ((^key << 0x15) + 0x11111111) | (key >> 0x0B)
+		seed = plain + seed + (seed << 5) + 3
+	}
+}
+
+func decrypt(data []uint32, key uint32) {
+	seed := uint32(0xEEEEEEEE)
+	for i, value := range data {
+		seed += cryptTable[0x400+(key&0xFF)]
+		plain := value ^ (key + seed)
+		key = ((^key << 0x15) + 0x11111111) | (key >> 0x0B)
+		seed = plain + seed + (seed << 5) + 3
+		data[i] = plain
+	}
+}
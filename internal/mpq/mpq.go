@@ -0,0 +1,263 @@
+// Package mpq writes (and reads back) version 1 MPQ archives, the format the 3.3.5 client loads
+// patch-X.MPQ files from. Only what a client patch needs is supported: no encryption, no
+// extended headers and zlib as the only compression.
+package mpq
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	headerSize = 32
+	// sectors are 512 << sectorShift bytes, 3 is the 4096 byte sectors Blizzard uses
+	sectorShift = 3
+	sectorSize  = 512 << sectorShift
+
+	fileExists   = 0x80000000
+	fileCompress = 0x00000200
+
+	compressionZlib = 0x02
+
+	hashEntryEmpty = 0xFFFFFFFF
+
+	// ListFile is the conventional list of every file in the archive, tools use it to show names
+	ListFile = "(listfile)"
+)
+
+var magic = [4]byte{'M', 'P', 'Q', 0x1A}
+
+type header struct {
+	Magic          [4]byte
+	HeaderSize     uint32
+	ArchiveSize    uint32
+	FormatVersion  uint16
+	SectorShift    uint16
+	HashTablePos   uint32
+	BlockTablePos  uint32
+	HashTableSize  uint32
+	BlockTableSize uint32
+}
+
+type hashEntry struct {
+	Name1      uint32
+	Name2      uint32
+	Locale     uint16
+	Platform   uint16
+	BlockIndex uint32
+}
+
+type blockEntry struct {
+	FilePos        uint32
+	CompressedSize uint32
+	FileSize       uint32
+	Flags          uint32
+}
+
+type file struct {
+	name string
+	data []byte
+}
+
+// Writer collects files and writes them out as a single archive
+type Writer struct {
+	// Compress zlib compresses every sector that gets smaller for it
+	Compress bool
+
+	files []file
+}
+
+func NewWriter(compress bool) *Writer {
+	return &Writer{Compress: compress}
+}
+
+// Add adds a file under its archive path e.g. DBFilesClient\Spell.dbc, adding the same path again replaces it
+func (w *Writer) Add(name string, data []byte) {
+	name = strings.ReplaceAll(name, "/", "\\")
+	for i := range w.files {
+		if strings.EqualFold(w.files[i].name, name) {
+			w.files[i].data = data
+			return
+		}
+	}
+	w.files = append(w.files, file{name: name, data: data})
+}
+
+// Names is every file added so far, sorted
+func (w *Writer) Names() []string {
+	names := make([]string, 0, len(w.files))
+	for _, f := range w.files {
+		names = append(names, f.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Write writes the archive, a (listfile) is added with the name of every file
+func (w *Writer) Write(out io.Writer) error {
+	files := append([]file{}, w.files...)
+	files = append(files, file{name: ListFile, data: []byte(strings.Join(w.Names(), "\r\n") + "\r\n")})
+
+	buffer := new(bytes.Buffer)
+	buffer.Write(make([]byte, headerSize))
+
+	blocks := make([]blockEntry, len(files))
+	for i, f := range files {
+		data, flags, err := w.encode(f.data)
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %v", f.name, err)
+		}
+
+		blocks[i] = blockEntry{
+			FilePos:        uint32(buffer.Len()),
+			CompressedSize: uint32(len(data)),
+			FileSize:       uint32(len(f.data)),
+			Flags:          flags,
+		}
+		buffer.Write(data)
+	}
+
+	hashes := make([]hashEntry, hashTableSize(len(files)))
+	for i := range hashes {
+		hashes[i] = hashEntry{Name1: hashEntryEmpty, Name2: hashEntryEmpty, Locale: 0xFFFF, Platform: 0xFFFF, BlockIndex: hashEntryEmpty}
+	}
+
+	mask := uint32(len(hashes) - 1)
+	for i, f := range files {
+		index := hashString(f.name, hashTableOffset) & mask
+		for hashes[index].BlockIndex != hashEntryEmpty {
+			index = (index + 1) & mask
+		}
+		hashes[index] = hashEntry{
+			Name1:      hashString(f.name, hashNameA),
+			Name2:      hashString(f.name, hashNameB),
+			BlockIndex: uint32(i),
+		}
+	}
+
+	hashTablePos := buffer.Len()
+	if err := writeEncrypted(buffer, hashes, hashString("(hash table)", hashFileKey)); err != nil {
+		return err
+	}
+	blockTablePos := buffer.Len()
+	if err := writeEncrypted(buffer, blocks, hashString("(block table)", hashFileKey)); err != nil {
+		return err
+	}
+
+	archive := buffer.Bytes()
+	head := header{
+		Magic:          magic,
+		HeaderSize:     headerSize,
+		ArchiveSize:    uint32(len(archive)),
+		FormatVersion:  0,
+		SectorShift:    sectorShift,
+		HashTablePos:   uint32(hashTablePos),
+		BlockTablePos:  uint32(blockTablePos),
+		HashTableSize:  uint32(len(hashes)),
+		BlockTableSize: uint32(len(blocks)),
+	}
+	headBuffer := new(bytes.Buffer)
+	if err := binary.Write(headBuffer, binary.LittleEndian, head); err != nil {
+		return err
+	}
+	copy(archive, headBuffer.Bytes())
+
+	_, err := out.Write(archive)
+	return err
+}
+
+// WriteFile writes the archive to path
+func (w *Writer) WriteFile(path string) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %v", path, err)
+	}
+
+	if err := w.Write(out); err != nil {
+		out.Close()
+		return err
+	}
+
+	return out.Close()
+}
+
+// encode returns the stored bytes and block flags for a file, compressed files are split into
+// sectors behind an offset table, a sector that does not shrink is stored as is
+func (w *Writer) encode(data []byte) ([]byte, uint32, error) {
+	if !w.Compress || len(data) == 0 {
+		return data, fileExists, nil
+	}
+
+	sectorCount := (len(data) + sectorSize - 1) / sectorSize
+	offsets := make([]uint32, sectorCount+1)
+	sectors := new(bytes.Buffer)
+	offsets[0] = uint32(len(offsets) * 4)
+
+	for i := 0; i < sectorCount; i++ {
+		end := (i + 1) * sectorSize
+		if end > len(data) {
+			end = len(data)
+		}
+		raw := data[i*sectorSize : end]
+
+		compressed := new(bytes.Buffer)
+		compressed.WriteByte(compressionZlib)
+		zw := zlib.NewWriter(compressed)
+		if _, err := zw.Write(raw); err != nil {
+			return nil, 0, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, 0, err
+		}
+
+		if compressed.Len() < len(raw) {
+			sectors.Write(compressed.Bytes())
+		} else {
+			sectors.Write(raw)
+		}
+		offsets[i+1] = offsets[0] + uint32(sectors.Len())
+	}
+
+	// not worth it, store the file as is
+	if int(offsets[sectorCount]) >= len(data) {
+		return data, fileExists, nil
+	}
+
+	out := new(bytes.Buffer)
+	if err := binary.Write(out, binary.LittleEndian, offsets); err != nil {
+		return nil, 0, err
+	}
+	out.Write(sectors.Bytes())
+
+	return out.Bytes(), fileExists | fileCompress, nil
+}
+
+// hash table size has to be a power of two, leave room so lookups stay short
+func hashTableSize(count int) int {
+	size := 16
+	for size < count*2 {
+		size *= 2
+	}
+	return size
+}
+
+func writeEncrypted(out io.Writer, table interface{}, key uint32) error {
+	raw := new(bytes.Buffer)
+	if err := binary.Write(raw, binary.LittleEndian, table); err != nil {
+		return err
+	}
+
+	values := make([]uint32, raw.Len()/4)
+	if err := binary.Read(raw, binary.LittleEndian, values); err != nil {
+		return err
+	}
+	encrypt(values, key)
+
+	return binary.Write(out, binary.LittleEndian, values)
+}
@@ -0,0 +1,102 @@
+package mpq
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestHashString(t *testing.T) {
+	// well known keys every MPQ implementation uses for the table encryption
+	tests := []struct {
+		name     string
+		hashType uint32
+		expected uint32
+	}{
+		{"(hash table)", hashFileKey, 0xC3AF3770},
+		{"(block table)", hashFileKey, 0xEC83B3A3},
+	}
+
+	for _, tt := range tests {
+		if got := hashString(tt.name, tt.hashType); got != tt.expected {
+			t.Errorf("hashString(%q, %d) = %#x, want %#x", tt.name, tt.hashType, got, tt.expected)
+		}
+	}
+
+	if hashString("DBFilesClient\\Spell.dbc", hashNameA) != hashString("dbfilesclient/spell.DBC", hashNameA) {
+		t.Error("paths should hash the same regardless of case and slash direction")
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	values := []uint32{1, 2, 3, 0xFFFFFFFF, 0}
+	data := append([]uint32{}, values...)
+
+	encrypt(data, 0xC3AF3770)
+	if reflect.DeepEqual(data, values) {
+		t.Fatal("encrypt did not change the data")
+	}
+	decrypt(data, 0xC3AF3770)
+	if !reflect.DeepEqual(data, values) {
+		t.Errorf("decrypt(encrypt(x)) = %v, want %v", data, values)
+	}
+}
+
+func TestWriteAndRead(t *testing.T) {
+	durability, err := os.ReadFile("../../data/dbc/DurabilityCosts.dbc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string][]byte{
+		"DBFilesClient\\DurabilityCosts.dbc": durability,
+		"DBFilesClient\\Item.dbc":            bytes.Repeat([]byte{0x57, 0x44, 0x42, 0x43}, 10),
+		"DBFilesClient\\Empty.dbc":           {},
+		"DBFilesClient\\Tiny.dbc":            {1, 2, 3},
+	}
+
+	for _, compress := range []bool{false, true} {
+		writer := NewWriter(compress)
+		for name, data := range files {
+			writer.Add(name, data)
+		}
+
+		out := new(bytes.Buffer)
+		if err := writer.Write(out); err != nil {
+			t.Fatal(err)
+		}
+
+		reader, err := NewReader(out.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for name, data := range files {
+			got, err := reader.ReadFile(name)
+			if err != nil {
+				t.Errorf("compress %v: %v", compress, err)
+				continue
+			}
+			if !bytes.Equal(got, data) {
+				t.Errorf("compress %v: %s read back %d bytes, want %d", compress, name, len(got), len(data))
+			}
+		}
+
+		names, err := reader.Files()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(names, writer.Names()) {
+			t.Errorf("listfile = %v, want %v", names, writer.Names())
+		}
+
+		if _, err := reader.ReadFile("DBFilesClient\\Missing.dbc"); err == nil {
+			t.Error("expected an error reading a missing file")
+		}
+
+		if compress && out.Len() >= len(durability) {
+			t.Errorf("compressed archive is %d bytes, DurabilityCosts alone is %d", out.Len(), len(durability))
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package mpq
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Reader reads files out of an archive written by Writer, enough to verify a patch before shipping it
+type Reader struct {
+	data   []byte
+	hashes []hashEntry
+	blocks []blockEntry
+}
+
+func OpenReader(path string) (*Reader, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	return NewReader(data)
+}
+
+func NewReader(data []byte) (*Reader, error) {
+	var head header
+	if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, &head); err != nil {
+		return nil, fmt.Errorf("failed to read MPQ header: %v", err)
+	}
+	if head.Magic != magic {
+		return nil, fmt.Errorf("invalid MPQ file: wrong magic identifier")
+	}
+
+	reader := &Reader{
+		data:   data,
+		hashes: make([]hashEntry, head.HashTableSize),
+		blocks: make([]blockEntry, head.BlockTableSize),
+	}
+
+	if err := readEncrypted(data, head.HashTablePos, reader.hashes, hashString("(hash table)", hashFileKey)); err != nil {
+		return nil, fmt.Errorf("failed to read hash table: %v", err)
+	}
+	if err := readEncrypted(data, head.BlockTablePos, reader.blocks, hashString("(block table)", hashFileKey)); err != nil {
+		return nil, fmt.Errorf("failed to read block table: %v", err)
+	}
+
+	return reader, nil
+}
+
+// Files lists the archive contents from its (listfile)
+func (r *Reader) Files() ([]string, error) {
+	list, err := r.ReadFile(ListFile)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, name := range strings.Split(string(list), "\r\n") {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// ReadFile returns the contents of the file at an archive path
+func (r *Reader) ReadFile(name string) ([]byte, error) {
+	if len(r.hashes) == 0 {
+		return nil, fmt.Errorf("%s not found", name)
+	}
+
+	mask := uint32(len(r.hashes) - 1)
+	name1 := hashString(name, hashNameA)
+	name2 := hashString(name, hashNameB)
+	start := hashString(name, hashTableOffset) & mask
+
+	for index := start; ; {
+		entry := r.hashes[index]
+		if entry.BlockIndex == hashEntryEmpty {
+			break
+		}
+		if entry.Name1 == name1 && entry.Name2 == name2 && int(entry.BlockIndex) < len(r.blocks) {
+			return r.readBlock(r.blocks[entry.BlockIndex])
+		}
+
+		index = (index + 1) & mask
+		if index == start {
+			break
+		}
+	}
+
+	return nil, fmt.Errorf("%s not found", name)
+}
+
+func (r *Reader) readBlock(block blockEntry) ([]byte, error) {
+	end := uint64(block.FilePos) + uint64(block.CompressedSize)
+	if end > uint64(len(r.data)) {
+		return nil, fmt.Errorf("block runs past the end of the archive")
+	}
+	stored := r.data[block.FilePos:end]
+
+	if block.Flags&fileCompress == 0 {
+		return stored, nil
+	}
+
+	sectorCount := (int(block.FileSize) + sectorSize - 1) / sectorSize
+	offsets := make([]uint32, sectorCount+1)
+	if err := binary.Read(bytes.NewReader(stored), binary.LittleEndian, offsets); err != nil {
+		return nil, fmt.Errorf("failed to read sector offsets: %v", err)
+	}
+
+	out := make([]byte, 0, block.FileSize)
+	for i := 0; i < sectorCount; i++ {
+		if offsets[i] > offsets[i+1] || int(offsets[i+1]) > len(stored) {
+			return nil, fmt.Errorf("bad sector offset %d", i)
+		}
+		sector := stored[offsets[i]:offsets[i+1]]
+
+		expected := sectorSize
+		if remaining := int(block.FileSize) - i*sectorSize; remaining < expected {
+			expected = remaining
+		}
+
+		// a sector stored at full size was not compressed
+		if len(sector) == expected {
+			out = append(out, sector...)
+			continue
+		}
+
+		if len(sector) == 0 || sector[0] != compressionZlib {
+			return nil, fmt.Errorf("unsupported compression in sector %d", i)
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(sector[1:]))
+		if err != nil {
+			return nil, err
+		}
+		raw, err := io.ReadAll(zr)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, raw...)
+	}
+
+	return out, nil
+}
+
+func readEncrypted(data []byte, pos uint32, table interface{}, key uint32) error {
+	size := binary.Size(table)
+	if uint64(pos)+uint64(size) > uint64(len(data)) {
+		return fmt.Errorf("table runs past the end of the archive")
+	}
+
+	values := make([]uint32, size/4)
+	if err := binary.Read(bytes.NewReader(data[pos:int(pos)+size]), binary.LittleEndian, values); err != nil {
+		return err
+	}
+	decrypt(values, key)
+
+	raw := new(bytes.Buffer)
+	if err := binary.Write(raw, binary.LittleEndian, values); err != nil {
+		return err
+	}
+	return binary.Read(raw, binary.LittleEndian, table)
+}
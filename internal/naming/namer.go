@@ -0,0 +1,217 @@
+package naming
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlrow"
+)
+
+// Item is what a generated name is picked for
+type Item struct {
+	Entry      int    // the generated entry, the same entry always gets the same name
+	Name       string // the source item's name
+	Difficulty int
+	Map        int    // dungeon or raid the source item drops in, 0 when unknown
+	Role       string // one of Roles
+}
+
+// Name is a picked name, Prefix is the difficulty word it starts with so it can be translated
+type Name struct {
+	Text   string
+	Prefix string
+}
+
+// Namer picks the names of generated items and remembers every name in use so no two items share one
+type Namer struct {
+	config *Config
+	taken  map[string]int // lower case name: the entry that has it
+}
+
+func NewNamer(config *Config) *Namer {
+	return &Namer{config: config, taken: map[string]int{}}
+}
+
+func (n *Namer) Config() *Config {
+	return n.config
+}
+
+// LoadTaken reads every item_template name so generated names never repeat an existing item. An item's
+// own entry does not count, a rerun gives it the name it already has.
+func (n *Namer) LoadTaken(db sqlrow.Querier) error {
+	rows := []struct {
+		Entry int    `db:"entry"`
+		Name  string `db:"name"`
+	}{}
+	if err := db.Select(&rows, "SELECT entry, name FROM item_template"); err != nil {
+		return fmt.Errorf("failed to read the item names: %w", err)
+	}
+	for _, row := range rows {
+		n.Take(row.Entry, row.Name)
+	}
+	return nil
+}
+
+// Take marks name as the name of entry
+func (n *Namer) Take(entry int, name string) {
+	n.taken[strings.ToLower(name)] = entry
+}
+
+func (n *Namer) free(entry int, name string) bool {
+	owner, ok := n.taken[strings.ToLower(name)]
+	return !ok || owner == entry
+}
+
+type part struct {
+	word   string
+	suffix bool
+}
+
+// Name picks the item's name and takes it. Every difficulty prefix combined with every theme and role
+// word (or none) is tried in an order picked from the entry, the first one that fits the length limit,
+// does not repeat a word of the source name and is not taken wins. When none does the source name is
+// cut to fit and numbered.
+func (n *Namer) Name(item Item) Name {
+	base := strings.Join(strings.Fields(item.Name), " ")
+	pool := n.config.Difficulties.For(item.Difficulty)
+
+	leads := []string{}
+	for _, prefix := range pool.Prefixes {
+		if !repeats(base, prefix) {
+			leads = append(leads, prefix)
+		}
+	}
+	if len(leads) == 0 {
+		leads = []string{""}
+	}
+
+	// an item name like Cloak of the Eagle already has a suffix
+	hasSuffix := strings.Contains(strings.ToLower(base), " of ")
+	extras := []part{}
+	add := func(p Pool) {
+		for _, prefix := range p.Prefixes {
+			if !repeats(base, prefix) {
+				extras = append(extras, part{word: prefix})
+			}
+		}
+		for _, suffix := range p.Suffixes {
+			if !hasSuffix && !repeats(base, suffix) {
+				extras = append(extras, part{word: suffix, suffix: true})
+			}
+		}
+	}
+	add(Pool{Suffixes: pool.Suffixes})
+	if theme, ok := n.config.Theme(item.Map); ok {
+		add(theme.Pool)
+	}
+	add(n.config.Roles[item.Role])
+	// no extra word is always a choice
+	extras = append(extras, part{})
+
+	h := hash(item.Entry, item.Difficulty)
+	first, firstExtra := int(h%uint64(len(leads))), int((h/uint64(len(leads)))%uint64(len(extras)))
+	for i := range leads {
+		lead := leads[(first+i)%len(leads)]
+		for j := range extras {
+			text := compose(lead, base, extras[(firstExtra+j)%len(extras)])
+			if utf8.RuneCountInString(text) <= n.config.MaxLength && n.free(item.Entry, text) {
+				return n.take(item.Entry, text, lead)
+			}
+		}
+	}
+
+	lead := leads[first]
+	for number := 1; ; number++ {
+		mark := ""
+		if number > 1 {
+			mark = " " + roman(number)
+		}
+		room := n.config.MaxLength - utf8.RuneCountInString(mark)
+		text := cut(compose(lead, base, part{}), room) + mark
+		if n.free(item.Entry, text) {
+			return n.take(item.Entry, text, lead)
+		}
+	}
+}
+
+func (n *Namer) take(entry int, text, lead string) Name {
+	n.Take(entry, text)
+	return Name{Text: text, Prefix: lead}
+}
+
+func compose(lead, base string, extra part) string {
+	words := []string{}
+	if lead != "" {
+		words = append(words, lead)
+	}
+	if extra.word != "" && !extra.suffix {
+		words = append(words, extra.word)
+	}
+	words = append(words, base)
+	if extra.suffix {
+		words = append(words, extra.word)
+	}
+	return strings.Join(words, " ")
+}
+
+// repeats is true when a word of phrase is already a word of name, so there is no Fabled Fabled Sword.
+// Short words like of and the do not count.
+func repeats(name, phrase string) bool {
+	have := map[string]bool{}
+	for _, word := range words(name) {
+		have[word] = true
+	}
+	for _, word := range words(phrase) {
+		if len(word) > 3 && have[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// cut shortens text to max characters at a word break when there is one
+func cut(text string, max int) string {
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	short := string(runes[:max])
+	if i := strings.LastIndex(short, " "); i > 0 {
+		short = short[:i]
+	}
+	return strings.TrimSpace(short)
+}
+
+func hash(entry, difficulty int) uint64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d/%d", entry, difficulty)
+	return h.Sum64()
+}
+
+func roman(number int) string {
+	numerals := []struct {
+		value  int
+		symbol string
+	}{{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"}, {100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"}, {10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"}}
+	out := ""
+	for _, numeral := range numerals {
+		for number >= numeral.value {
+			out += numeral.symbol
+			number -= numeral.value
+		}
+	}
+	return out
+}
+
+var current = NewNamer(defaultConfig)
+
+// Use makes namer the one generated items are named with
+func Use(namer *Namer) {
+	current = namer
+}
+
+func Current() *Namer {
+	return current
+}
@@ -0,0 +1,288 @@
+package naming
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/locale"
+)
+
+// Version is the naming file format this build reads
+const Version = 1
+
+// Pool is the words a generated name is built from, prefixes go in front of the source item's name and
+// suffixes after it
+type Pool struct {
+	Prefixes []string `json:"prefixes,omitempty"`
+	Suffixes []string `json:"suffixes,omitempty"`
+}
+
+type Difficulties struct {
+	Mythic    Pool `json:"mythic"`
+	Legendary Pool `json:"legendary"`
+	Ascendant Pool `json:"ascendant"`
+}
+
+// For is the pool of difficulty 3-5, an empty one for the others
+func (d Difficulties) For(difficulty int) Pool {
+	switch difficulty {
+	case 3:
+		return d.Mythic
+	case 4:
+		return d.Legendary
+	case 5:
+		return d.Ascendant
+	default:
+		return Pool{}
+	}
+}
+
+// Theme is the pool of one dungeon or raid
+type Theme struct {
+	Maps []int `json:"maps"`
+	// words in a source item's name that mark it as belonging to the theme, raid-gear gives these fire resistance
+	Keywords []string `json:"keywords,omitempty"`
+	Pool
+}
+
+// Matches is true when name has one of the theme's keywords
+func (t Theme) Matches(name string) bool {
+	name = strings.ToLower(name)
+	for _, keyword := range t.Keywords {
+		if strings.Contains(name, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Roles are the keys of Config.Roles, the item's class user type (see items.GetClassUserType) is the index
+var Roles = []string{"", "strength", "agility", "ranged", "caster", "healer", "tank"}
+
+// Config is every word pool generated names are picked from.
+//
+// A name is the difficulty's prefix, then maybe one theme or role prefix, the source item's name and
+// maybe one suffix. The difficulty prefixes are what keep the tiers apart, so every difficulty needs
+// at least one and no word can be in two of them or in any other pool.
+type Config struct {
+	Version int `json:"version"`
+	// MaxLength is the longest name written, in characters. Longer names are cut off in the client's
+	// tooltips and item links.
+	MaxLength    int              `json:"maxLength"`
+	Difficulties Difficulties     `json:"difficulties"`
+	Themes       map[string]Theme `json:"themes"`
+	Roles        map[string]Pool  `json:"roles"`
+}
+
+var defaultConfig = &Config{
+	Version:   Version,
+	MaxLength: 64,
+	Difficulties: Difficulties{
+		Mythic:    Pool{Prefixes: locale.English.Mythic},
+		Legendary: Pool{Prefixes: locale.English.Legendary},
+		Ascendant: Pool{Prefixes: locale.English.Ascendant},
+	},
+	Themes: map[string]Theme{
+		"molten-core": {
+			Maps: []int{409},
+			Keywords: []string{
+				"flame", "fire", "salamander", "crimson", "burning",
+				"blazing", "infernal", "molten", "ember", "igniting",
+				"flamewalker", "flameguard",
+			},
+			Pool: Pool{
+				Prefixes: []string{"Molten", "Smoldering", "Searing"},
+				Suffixes: []string{"of the Firelord", "of Living Flame", "of the Core Hound"},
+			},
+		},
+		"blackrock-depths": {
+			Maps: []int{230},
+			Pool: Pool{Suffixes: []string{"of the Shadowforge", "of the Dark Iron"}},
+		},
+		"scholomance": {
+			Maps: []int{289},
+			Pool: Pool{Suffixes: []string{"of the Necromancer", "of Dark Rites"}},
+		},
+		"stratholme": {
+			Maps: []int{329},
+			Pool: Pool{Suffixes: []string{"of the Scourge", "of the Scarlet Bastion"}},
+		},
+		"utgarde": {
+			Maps: []int{574, 575},
+			Pool: Pool{Suffixes: []string{"of the Vrykul", "of Ymiron"}},
+		},
+		"ulduar-halls": {
+			Maps: []int{599, 602},
+			Pool: Pool{Suffixes: []string{"of the Titans", "of the Stormforged"}},
+		},
+	},
+	Roles: map[string]Pool{
+		"strength": {Suffixes: []string{"of the Bear", "of the Champion"}},
+		"agility":  {Suffixes: []string{"of the Tiger", "of the Monkey"}},
+		"ranged":   {Suffixes: []string{"of the Falcon", "of the Marksman"}},
+		"caster":   {Suffixes: []string{"of the Sorcerer", "of Arcane Wrath"}},
+		"healer":   {Suffixes: []string{"of the Prophet", "of Restoration"}},
+		"tank":     {Suffixes: []string{"of the Guardian", "of the Bulwark"}},
+	},
+}
+
+// DefaultConfig is the built in pools the tools use without -names, names/default.json is the same
+func DefaultConfig() *Config {
+	return defaultConfig
+}
+
+// Load reads and validates the pools at path, an empty path is the built in pools
+func Load(path string) (*Config, error) {
+	if path == "" {
+		return defaultConfig, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read names %s: %w", path, err)
+	}
+	config := &Config{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse names %s: %w", path, err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("names %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Validate checks the version, that every difficulty has prefixes of its own that no other pool uses
+// and that every theme and role is one the generator can pick
+func (c *Config) Validate() error {
+	if c.Version != Version {
+		return fmt.Errorf("unsupported names version %d, expected %d", c.Version, Version)
+	}
+	// the shortest name has to fit a prefix, a short item name and a numeral
+	if c.MaxLength < 20 {
+		return fmt.Errorf("maxLength %d is too short", c.MaxLength)
+	}
+
+	// difficulty prefix words and the difficulty they belong to
+	tiers := map[string]int{}
+	for difficulty := 3; difficulty <= 5; difficulty++ {
+		pool := c.Difficulties.For(difficulty)
+		if len(pool.Prefixes) == 0 {
+			return fmt.Errorf("difficulty %d has no prefixes", difficulty)
+		}
+		for _, prefix := range pool.Prefixes {
+			if err := checkWords(prefix); err != nil {
+				return err
+			}
+			for _, word := range words(prefix) {
+				if other, ok := tiers[word]; ok {
+					return fmt.Errorf("%q is a prefix of difficulty %d and %d", word, other, difficulty)
+				}
+				tiers[word] = difficulty
+			}
+		}
+	}
+
+	check := func(owner string, pool Pool) error {
+		for _, phrase := range append(append([]string{}, pool.Prefixes...), pool.Suffixes...) {
+			if err := checkWords(phrase); err != nil {
+				return fmt.Errorf("%s: %w", owner, err)
+			}
+			for _, word := range words(phrase) {
+				if difficulty, ok := tiers[word]; ok {
+					return fmt.Errorf("%s: %q has %q, a prefix of difficulty %d", owner, phrase, word, difficulty)
+				}
+			}
+		}
+		return nil
+	}
+
+	for difficulty := 3; difficulty <= 5; difficulty++ {
+		if err := check(fmt.Sprintf("difficulty %d", difficulty), Pool{Suffixes: c.Difficulties.For(difficulty).Suffixes}); err != nil {
+			return err
+		}
+	}
+
+	maps := map[int]string{}
+	for _, name := range c.ThemeNames() {
+		theme := c.Themes[name]
+		if len(theme.Maps) == 0 {
+			return fmt.Errorf("theme %s has no maps", name)
+		}
+		for _, id := range theme.Maps {
+			if other, ok := maps[id]; ok {
+				return fmt.Errorf("map %d is in theme %s and %s", id, other, name)
+			}
+			maps[id] = name
+		}
+		if err := check("theme "+name, theme.Pool); err != nil {
+			return err
+		}
+	}
+
+	for role, pool := range c.Roles {
+		if role == "" || !knownRole(role) {
+			return fmt.Errorf("unknown role %q, use one of %v", role, Roles[1:])
+		}
+		if err := check("role "+role, pool); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ThemeNames in a fixed order so validation errors come out the same every run
+func (c *Config) ThemeNames() []string {
+	names := make([]string, 0, len(c.Themes))
+	for name := range c.Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Theme is the theme of the dungeon or raid with map id, false when it has none
+func (c *Config) Theme(mapId int) (Theme, bool) {
+	for _, theme := range c.Themes {
+		for _, id := range theme.Maps {
+			if id == mapId {
+				return theme, true
+			}
+		}
+	}
+	return Theme{}, false
+}
+
+func (c *Config) String() string {
+	out, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("failed to print names: %v", err)
+	}
+	return string(out)
+}
+
+func checkWords(phrase string) error {
+	if strings.TrimSpace(phrase) == "" {
+		return fmt.Errorf("empty word in a pool")
+	}
+	if phrase != strings.Join(strings.Fields(phrase), " ") {
+		return fmt.Errorf("%q has extra spaces", phrase)
+	}
+	return nil
+}
+
+// words are the lower case words of phrase
+func words(phrase string) []string {
+	return strings.Fields(strings.ToLower(phrase))
+}
+
+func knownRole(role string) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
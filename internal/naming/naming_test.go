@@ -0,0 +1,136 @@
+package naming
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestDefaultNamesFile(t *testing.T) {
+	config, err := Load("../../names/default.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(config, DefaultConfig()) {
+		t.Error("names/default.json is out of date with the built in pools")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	edit := func(change func(c *Config)) *Config {
+		c := &Config{}
+		*c = *DefaultConfig()
+		c.Themes = map[string]Theme{"molten-core": DefaultConfig().Themes["molten-core"]}
+		c.Roles = map[string]Pool{}
+		change(c)
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		config *Config
+		valid  bool
+	}{
+		{"default", DefaultConfig(), true},
+		{"old version", edit(func(c *Config) { c.Version = 0 }), false},
+		{"too short", edit(func(c *Config) { c.MaxLength = 10 }), false},
+		{"no mythic prefixes", edit(func(c *Config) { c.Difficulties.Mythic = Pool{} }), false},
+		{"prefix in two tiers", edit(func(c *Config) { c.Difficulties.Mythic = Pool{Prefixes: []string{"Mythic", "Fabled"}} }), false},
+		{"tier word in a suffix", edit(func(c *Config) { c.Roles["tank"] = Pool{Suffixes: []string{"of the Mighty"}} }), false},
+		{"unknown role", edit(func(c *Config) { c.Roles["bard"] = Pool{Suffixes: []string{"of Song"}} }), false},
+		{"map in two themes", edit(func(c *Config) { c.Themes["fire"] = Theme{Maps: []int{409}} }), false},
+		{"theme without maps", edit(func(c *Config) { c.Themes["nowhere"] = Theme{} }), false},
+		{"empty word", edit(func(c *Config) { c.Roles["tank"] = Pool{Prefixes: []string{" "}} }), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.config.Validate()
+			if test.valid && err != nil {
+				t.Errorf("expected valid, got %v", err)
+			}
+			if !test.valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		name string
+		item Item
+		// the name has to start with one of these and must not contain any of the others
+		starts  []string
+		without []string
+	}{
+		{"legendary", Item{Entry: 21000100, Name: "Sword", Difficulty: 4}, DefaultConfig().Difficulties.Legendary.Prefixes, nil},
+		{"no repeated prefix", Item{Entry: 21000101, Name: "Fabled Sword", Difficulty: 4}, DefaultConfig().Difficulties.Legendary.Prefixes, []string{"Fabled Fabled"}},
+		{"no second suffix", Item{Entry: 20000102, Name: "Cloak of the Eagle", Difficulty: 3, Role: "tank"}, DefaultConfig().Difficulties.Mythic.Prefixes, []string{"Guardian", "Bulwark"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			name := NewNamer(DefaultConfig()).Name(test.item)
+			if !strings.HasPrefix(name.Text, name.Prefix+" ") || !contains(test.starts, name.Prefix) {
+				t.Errorf("%q does not start with one of %v", name.Text, test.starts)
+			}
+			for _, word := range test.without {
+				if strings.Contains(name.Text, word) {
+					t.Errorf("%q has %q", name.Text, word)
+				}
+			}
+			if again := NewNamer(DefaultConfig()).Name(test.item); again != name {
+				t.Errorf("same item got %q then %q", name.Text, again.Text)
+			}
+		})
+	}
+}
+
+func TestNameIsUnique(t *testing.T) {
+	namer := NewNamer(DefaultConfig())
+	seen := map[string]int{}
+	for difficulty := 3; difficulty <= 5; difficulty++ {
+		for entry := 0; entry < 200; entry++ {
+			generated := difficulty*1000000 + entry
+			name := namer.Name(Item{Entry: generated, Name: "Sword", Difficulty: difficulty, Map: 409, Role: "strength"})
+			if other, ok := seen[name.Text]; ok {
+				t.Fatalf("%d and %d are both %q", other, generated, name.Text)
+			}
+			seen[name.Text] = generated
+
+			// asking again for the same entry gives the name it already has
+			if again := namer.Name(Item{Entry: generated, Name: "Sword", Difficulty: difficulty, Map: 409, Role: "strength"}); again != name {
+				t.Fatalf("%d got %q then %q", generated, name.Text, again.Text)
+			}
+		}
+	}
+
+	// names already in item_template are not handed out again
+	namer = NewNamer(DefaultConfig())
+	item := Item{Entry: 20000100, Name: "Sword", Difficulty: 3}
+	first := NewNamer(DefaultConfig()).Name(item)
+	namer.Take(100, first.Text)
+	if name := namer.Name(item); name.Text == first.Text {
+		t.Errorf("expected a name other than %q, it belongs to item 100", first.Text)
+	}
+}
+
+func TestNameLength(t *testing.T) {
+	namer := NewNamer(DefaultConfig())
+	long := strings.Repeat("Incredibly Long ", 6) + "Sword"
+	for entry := 0; entry < 20; entry++ {
+		name := namer.Name(Item{Entry: entry, Name: long, Difficulty: 5})
+		if utf8.RuneCountInString(name.Text) > DefaultConfig().MaxLength {
+			t.Errorf("%q is longer than %d", name.Text, DefaultConfig().MaxLength)
+		}
+	}
+}
+
+func contains(list []string, word string) bool {
+	for _, w := range list {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
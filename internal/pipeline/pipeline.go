@@ -0,0 +1,85 @@
+package pipeline
+
+import "sync"
+
+// Ordered runs work on every input on up to workers goroutines and hands each result to emit in the
+// order of inputs, so the output is the same as running them one at a time. emit always runs on the
+// calling goroutine. At most a few results per worker wait on a slow earlier input before the workers
+// are held back. The first error emit returns stops the run, no more inputs are started and the
+// results still being worked on are dropped, and Ordered returns it.
+func Ordered[In, Out any](inputs []In, workers int, work func(In) Out, emit func(Out) error) error {
+	if workers <= 1 {
+		for _, input := range inputs {
+			if err := emit(work(input)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	type result struct {
+		index int
+		out   Out
+	}
+
+	// inputs handed out but not emitted yet, the earliest of them is always being worked on
+	window := make(chan struct{}, workers*4)
+	jobs := make(chan int)
+	results := make(chan result)
+	stop := make(chan struct{})
+
+	go func() {
+		defer close(jobs)
+		for i := range inputs {
+			select {
+			case window <- struct{}{}:
+			case <-stop:
+				return
+			}
+			select {
+			case jobs <- i:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results <- result{index: i, out: work(inputs[i])}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var err error
+	pending := map[int]Out{}
+	next := 0
+	for r := range results {
+		if err != nil {
+			continue
+		}
+		pending[r.index] = r.out
+		for err == nil {
+			out, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			if err = emit(out); err != nil {
+				close(stop)
+				break
+			}
+			<-window
+			next++
+		}
+	}
+	return err
+}
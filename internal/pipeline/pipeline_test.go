@@ -0,0 +1,87 @@
+package pipeline
+
+import (
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOrdered(t *testing.T) {
+	inputs := make([]int, 200)
+	for i := range inputs {
+		inputs[i] = i
+	}
+
+	for _, workers := range []int{0, 1, 4, 16} {
+		var running, most atomic.Int32
+		work := func(n int) int {
+			now := running.Add(1)
+			defer running.Add(-1)
+			for {
+				seen := most.Load()
+				if now <= seen || most.CompareAndSwap(seen, now) {
+					break
+				}
+			}
+			// later inputs finish first
+			time.Sleep(time.Duration(len(inputs)-n) * time.Microsecond * 20)
+			return n * n
+		}
+
+		got := []int{}
+		err := Ordered(inputs, workers, work, func(out int) error {
+			got = append(got, out)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := make([]int, len(inputs))
+		for i := range want {
+			want[i] = i * i
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%d workers: results out of order", workers)
+		}
+		if limit := int32(max(workers, 1)); most.Load() > limit {
+			t.Errorf("%d workers: %d ran at once", workers, most.Load())
+		}
+	}
+}
+
+func TestOrderedStops(t *testing.T) {
+	inputs := make([]int, 200)
+	for i := range inputs {
+		inputs[i] = i
+	}
+	stop := errors.New("stop")
+
+	for _, workers := range []int{1, 4, 16} {
+		var worked atomic.Int32
+		work := func(n int) int {
+			worked.Add(1)
+			return n
+		}
+
+		got := []int{}
+		err := Ordered(inputs, workers, work, func(out int) error {
+			got = append(got, out)
+			if out == 10 {
+				return stop
+			}
+			return nil
+		})
+		if err != stop {
+			t.Errorf("%d workers: expected the emit error, got %v", workers, err)
+		}
+		if len(got) != 11 {
+			t.Errorf("%d workers: expected 11 results before the stop, got %d", workers, len(got))
+		}
+		if worked.Load() == int32(len(inputs)) {
+			t.Errorf("%d workers: every input was worked on after the stop", workers)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gocarina/gocsv"
+)
+
+type Status string
+
+const (
+	Generated Status = "generated"
+	Skipped   Status = "skipped"
+	Failed    Status = "failed"
+)
+
+// Reason codes say which path an item took, a generated item's reason is the kind of drop it is
+const (
+	DungeonDrop = "dungeon-drop"
+	BossDrop    = "boss-drop"
+	FinalBoss   = "final-boss-drop"
+	WorldDrop   = "world-drop" // not from a dungeon
+
+	NotFromDungeon     = "not-from-dungeon"
+	NoStatList         = "no-stat-list"
+	NoReference        = "no-reference-item"
+	ReferenceMissing   = "reference-item-missing"
+	NoLowerDifficulty  = "no-lower-difficulty-item"
+	NoItemRule         = "no-item-rule" // no rule of the profile matches where the item drops
+	DungeonLookupError = "dungeon-lookup-failed"
+	ScaleFailed        = "scale-failed"
+)
+
+// Outcome is what happened to one source item
+type Outcome struct {
+	Entry          int    `json:"entry" csv:"entry"`
+	Name           string `json:"name" csv:"name"`
+	Status         Status `json:"status" csv:"status"`
+	Reason         string `json:"reason" csv:"reason"`
+	Detail         string `json:"detail,omitempty" csv:"detail"`
+	GeneratedEntry int    `json:"generatedEntry,omitempty" csv:"generated_entry"`
+	ItemLevel      int    `json:"itemLevel,omitempty" csv:"item_level"`
+	RequiredLevel  int    `json:"requiredLevel,omitempty" csv:"required_level"`
+	Reference      int    `json:"reference,omitempty" csv:"reference"` // the item the stats were borrowed from
+	Map            int    `json:"map,omitempty" csv:"map"`
+	Boss           int    `json:"boss,omitempty" csv:"boss"` // creature entry for boss drops
+}
+
+// Report is every source item a run looked at in the order it looked at them
+type Report struct {
+	Tool       string    `json:"tool"`
+	Seed       uint64    `json:"seed"`
+	Difficulty int       `json:"difficulty"`
+	Where      string    `json:"where,omitempty"` // the -where filter, items it left out are not in the report
+	Outcomes   []Outcome `json:"outcomes"`
+}
+
+func New(tool string, seed uint64, difficulty int) *Report {
+	return &Report{Tool: tool, Seed: seed, Difficulty: difficulty, Outcomes: []Outcome{}}
+}
+
+func (r *Report) Add(outcome Outcome) {
+	r.Outcomes = append(r.Outcomes, outcome)
+}
+
+// Count is how many outcomes have status
+func (r *Report) Count(status Status) int {
+	count := 0
+	for _, outcome := range r.Outcomes {
+		if outcome.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// PrintSummary writes the totals and a table of how many items took each path
+func (r *Report) PrintSummary(w io.Writer) {
+	total := len(r.Outcomes)
+	generated := r.Count(Generated)
+	rate := 0.0
+	if total > 0 {
+		rate = float64(generated) / float64(total) * 100
+	}
+
+	fmt.Fprintf(w, "\nGeneration Summary:\n")
+	fmt.Fprintf(w, "Total Items: %d\n", total)
+	fmt.Fprintf(w, "Generated: %d\n", generated)
+	fmt.Fprintf(w, "Skipped: %d\n", r.Count(Skipped))
+	fmt.Fprintf(w, "Failed: %d\n", r.Count(Failed))
+	fmt.Fprintf(w, "Success Rate: %.1f%%\n\n", rate)
+
+	type key struct {
+		status Status
+		reason string
+	}
+	counts := map[key]int{}
+	for _, outcome := range r.Outcomes {
+		counts[key{outcome.Status, outcome.Reason}]++
+	}
+	keys := make([]key, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	order := map[Status]int{Generated: 0, Skipped: 1, Failed: 2}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].status != keys[j].status {
+			return order[keys[i].status] < order[keys[j].status]
+		}
+		return keys[i].reason < keys[j].reason
+	})
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "STATUS\tREASON\tITEMS")
+	for _, k := range keys {
+		fmt.Fprintf(table, "%s\t%s\t%d\n", k.status, k.reason, counts[k])
+	}
+	table.Flush()
+}
+
+func (r *Report) WriteJson(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteCsv writes one row per outcome, the run settings are left out
+func (r *Report) WriteCsv(w io.Writer) error {
+	return gocsv.Marshal(r.Outcomes, w)
+}
+
+// WriteFile writes the report as csv when path ends in .csv and as json otherwise
+func (r *Report) WriteFile(path string) error {
+	write := r.WriteJson
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		write = r.WriteCsv
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to write report %s: %w", path, err)
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write report %s: %w", path, err)
+	}
+	return file.Close()
+}
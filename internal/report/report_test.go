@@ -0,0 +1,81 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func sample() *Report {
+	r := New("test", 42, 3)
+	r.Add(Outcome{Entry: 1, Name: "Sword", Status: Generated, Reason: DungeonDrop, GeneratedEntry: 20000001, ItemLevel: 300})
+	r.Add(Outcome{Entry: 2, Name: "Helm", Status: Generated, Reason: BossDrop, Boss: 11502})
+	r.Add(Outcome{Entry: 3, Name: "Ring", Status: Skipped, Reason: NoStatList})
+	r.Add(Outcome{Entry: 4, Name: "Cloak", Status: Skipped, Reason: NoStatList})
+	r.Add(Outcome{Entry: 5, Name: "Axe", Status: Failed, Reason: ReferenceMissing, Detail: "no item 99"})
+	return r
+}
+
+func TestPrintSummary(t *testing.T) {
+	var out bytes.Buffer
+	sample().PrintSummary(&out)
+	summary := out.String()
+
+	for _, line := range []string{"Total Items: 5", "Generated: 2", "Skipped: 2", "Failed: 1", "Success Rate: 40.0%"} {
+		if !strings.Contains(summary, line) {
+			t.Errorf("summary is missing %q:\n%s", line, summary)
+		}
+	}
+
+	// generated rows come first, then skipped then failed
+	rows := strings.Split(strings.TrimSpace(summary[strings.Index(summary, "STATUS"):]), "\n")
+	expected := [][]string{
+		{"STATUS", "REASON", "ITEMS"},
+		{"generated", BossDrop, "1"},
+		{"generated", DungeonDrop, "1"},
+		{"skipped", NoStatList, "2"},
+		{"failed", ReferenceMissing, "1"},
+	}
+	if len(rows) != len(expected) {
+		t.Fatalf("expected %d rows, got:\n%s", len(expected), summary)
+	}
+	for i, row := range rows {
+		if fields := strings.Fields(row); strings.Join(fields, " ") != strings.Join(expected[i], " ") {
+			t.Errorf("row %d is %v, expected %v", i, fields, expected[i])
+		}
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	r := sample()
+
+	jsonPath := filepath.Join(dir, "report.json")
+	if err := r.WriteFile(jsonPath); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(jsonPath)
+	read := Report{}
+	if err := json.Unmarshal(data, &read); err != nil {
+		t.Fatal(err)
+	}
+	if read.Seed != 42 || len(read.Outcomes) != 5 || read.Outcomes[4].Detail != "no item 99" {
+		t.Errorf("json report does not match: %+v", read)
+	}
+
+	csvPath := filepath.Join(dir, "report.CSV")
+	if err := r.WriteFile(csvPath); err != nil {
+		t.Fatal(err)
+	}
+	data, _ = os.ReadFile(csvPath)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 6 || !strings.HasPrefix(lines[0], "entry,name,status,reason") {
+		t.Errorf("expected a header and 5 rows, got:\n%s", data)
+	}
+	if !strings.HasPrefix(lines[3], "3,Ring,skipped,"+NoStatList) {
+		t.Errorf("unexpected row %q", lines[3])
+	}
+}
@@ -0,0 +1,127 @@
+package revert
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlrow"
+)
+
+// Script undoes one generation run. Every row the run writes is looked up before the write,
+// rows that did not exist are deleted on revert and rows that did are put back as they were.
+type Script struct {
+	db         sqlrow.Querier
+	schema     string
+	statements []string
+	seen       map[string]bool
+}
+
+// New reads from db, schema is put in front of the table names in the script (acore_world), empty for none
+func New(db sqlrow.Querier, schema string) *Script {
+	return &Script{
+		db:     db,
+		schema: schema,
+		seen:   map[string]bool{},
+	}
+}
+
+// Track records how to undo the write of table.key = id, call it before the row is written.
+// Only the first call for a row counts so a row written twice is restored to what was there before the run.
+func (s *Script) Track(table, key string, id int) error {
+	name := fmt.Sprintf("%s.%s.%d", table, key, id)
+	if s.seen[name] {
+		return nil
+	}
+
+	row, found, err := sqlrow.Read(s.db, table, key, id)
+	if err != nil {
+		return fmt.Errorf("failed to read the row for the revert script: %w", err)
+	}
+
+	statement := fmt.Sprintf("DELETE FROM %s WHERE %s = %d;", s.table(table), sqlrow.QuoteIdent(key), id)
+	if found {
+		statement = fmt.Sprintf("REPLACE INTO %s (%s) VALUES (%s);", s.table(table), row.ColumnList(), row.ValueList())
+	}
+
+	s.seen[name] = true
+	s.statements = append(s.statements, statement)
+	return nil
+}
+
+// TrackAll is Track for tables with several rows per key like item_template_locale, every row of the key
+// is deleted on revert and the ones there before the run are put back
+func (s *Script) TrackAll(table, key string, id int) error {
+	name := fmt.Sprintf("%s.%s.%d", table, key, id)
+	if s.seen[name] {
+		return nil
+	}
+
+	rows, err := sqlrow.ReadAll(s.db, table, key, id)
+	if err != nil {
+		return fmt.Errorf("failed to read the rows for the revert script: %w", err)
+	}
+
+	statements := []string{fmt.Sprintf("DELETE FROM %s WHERE %s = %d;", s.table(table), sqlrow.QuoteIdent(key), id)}
+	for _, row := range rows {
+		statements = append(statements, fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);", s.table(table), row.ColumnList(), row.ValueList()))
+	}
+
+	s.seen[name] = true
+	s.statements = append(s.statements, strings.Join(statements, "\n"))
+	return nil
+}
+
+// Count is the number of rows the script reverts
+func (s *Script) Count() int {
+	return len(s.statements)
+}
+
+// Write the script, rows are reverted newest first in one transaction
+func (s *Script) Write(w io.Writer) error {
+	out := new(strings.Builder)
+	fmt.Fprintf(out, "-- reverts %d rows\n", len(s.statements))
+	out.WriteString("START TRANSACTION;\n")
+	for i := len(s.statements) - 1; i >= 0; i-- {
+		out.WriteString(s.statements[i])
+		out.WriteString("\n")
+	}
+	out.WriteString("COMMIT;\n")
+
+	_, err := io.WriteString(w, out.String())
+	return err
+}
+
+// Free fails when there already is a file on path. A revert script is the only way back from the run
+// that wrote it, so the next run never writes over it.
+func Free(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("revert script %s already exists, move it away or pick another -revert", path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// WriteFile writes the script to a new file, it fails when path already exists
+func (s *Script) WriteFile(path string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create revert script: %w", err)
+	}
+
+	if err := s.Write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write revert script %s: %w", path, err)
+	}
+	return file.Close()
+}
+
+func (s *Script) table(table string) string {
+	if s.schema == "" {
+		return table
+	}
+	return s.schema + "." + table
+}
@@ -0,0 +1,102 @@
+package revert
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestScript(t *testing.T) {
+	db, err := sqlx.Open("sqlite3", t.TempDir()+"/world.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	db.MustExec("CREATE TABLE item_template (entry INTEGER PRIMARY KEY, name TEXT, speed REAL, description TEXT)")
+	db.MustExec("INSERT INTO item_template VALUES (20000001, 'Mythic Sword', 2.5, NULL)")
+	db.MustExec("INSERT INTO item_template VALUES (20000002, 'Hunter''s Bow', 3, 'a \\ b')")
+
+	script := New(db, "acore_world")
+	for _, entry := range []int{20000001, 20000003, 20000002, 20000001} {
+		if err := script.Track("item_template", "entry", entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the second write of 20000001 happens after the run changed it, the first read is the one to restore
+	db.MustExec("UPDATE item_template SET name = 'Fabled Sword' WHERE entry = 20000001")
+	if err := script.Track("item_template", "entry", 20000001); err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(bytes.Buffer)
+	if err := script.Write(out); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "-- reverts 3 rows\n" +
+		"START TRANSACTION;\n" +
+		"REPLACE INTO acore_world.item_template (`entry`, `name`, `speed`, `description`) VALUES (20000002, 'Hunter''s Bow', 3, 'a \\\\ b');\n" +
+		"DELETE FROM acore_world.item_template WHERE `entry` = 20000003;\n" +
+		"REPLACE INTO acore_world.item_template (`entry`, `name`, `speed`, `description`) VALUES (20000001, 'Mythic Sword', 2.5, NULL);\n" +
+		"COMMIT;\n"
+	if out.String() != expected {
+		t.Errorf("script =\n%s\nwant\n%s", out.String(), expected)
+	}
+}
+
+func TestTrackAll(t *testing.T) {
+	db, err := sqlx.Open("sqlite3", t.TempDir()+"/world.db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	db.MustExec("CREATE TABLE item_template_locale (ID INTEGER, locale TEXT, Name TEXT)")
+	db.MustExec("INSERT INTO item_template_locale VALUES (20000001, 'deDE', 'Schwert'), (20000001, 'frFR', 'Épée')")
+
+	script := New(db, "")
+	for _, id := range []int{20000001, 20000002} {
+		if err := script.TrackAll("item_template_locale", "ID", id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := new(bytes.Buffer)
+	if err := script.Write(out); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "-- reverts 2 rows\n" +
+		"START TRANSACTION;\n" +
+		"DELETE FROM item_template_locale WHERE `ID` = 20000002;\n" +
+		"DELETE FROM item_template_locale WHERE `ID` = 20000001;\n" +
+		"INSERT INTO item_template_locale (`ID`, `locale`, `Name`) VALUES (20000001, 'deDE', 'Schwert');\n" +
+		"INSERT INTO item_template_locale (`ID`, `locale`, `Name`) VALUES (20000001, 'frFR', 'Épée');\n" +
+		"COMMIT;\n"
+	if out.String() != expected {
+		t.Errorf("script =\n%s\nwant\n%s", out.String(), expected)
+	}
+}
+
+func TestWriteFileKeepsExisting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "revert.sql")
+	if err := Free(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := New(nil, "").WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// a second run must not write over the script that undoes the first one
+	if err := Free(path); err == nil {
+		t.Error("expected Free to fail on an existing script")
+	}
+	if err := New(nil, "").WriteFile(path); err == nil {
+		t.Error("expected WriteFile to refuse an existing script")
+	}
+}
@@ -0,0 +1,64 @@
+package rng
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// Every random choice the generators make goes through this one source so a run
+// can be repeated exactly by passing the same -seed.
+var (
+	mu     sync.Mutex
+	seed   = uint64(time.Now().UnixNano())
+	source = rand.New(rand.NewPCG(seed, 2))
+)
+
+// Seed resets the source, the same seed gives the same sequence of choices
+func Seed(value uint64) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	seed = value
+	source = rand.New(rand.NewPCG(seed, 2))
+}
+
+// CurrentSeed is the seed of the running source, printed in the output so a run without -seed can be repeated
+func CurrentSeed() uint64 {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return seed
+}
+
+// IntN returns a number in [0,n) from the shared source
+func IntN(n int) int {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return source.IntN(n)
+}
+
+// Source is a stream of choices of its own for work that runs in parallel, its choices do not depend
+// on what else drew from the shared source before it
+type Source struct {
+	r *rand.Rand
+}
+
+// For is the source of one item, picked from the seed and key (the item's entry) so an item gets the
+// same choices whatever order the items are worked on in. The shared source uses stream 2, keys get
+// the odd streams so they never repeat it.
+func For(key int) *Source {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return &Source{r: rand.New(rand.NewPCG(seed, uint64(key)<<1|1))}
+}
+
+// IntN returns a number in [0,n), a nil source draws from the shared one
+func (s *Source) IntN(n int) int {
+	if s == nil {
+		return IntN(n)
+	}
+	return s.r.IntN(n)
+}
@@ -0,0 +1,63 @@
+package rng
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSeedRepeats(t *testing.T) {
+	draw := func() []int {
+		values := []int{}
+		for i := 0; i < 20; i++ {
+			values = append(values, IntN(1000))
+		}
+		return values
+	}
+
+	Seed(42)
+	first := draw()
+	Seed(42)
+	second := draw()
+	Seed(43)
+	third := draw()
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("same seed gave %v then %v", first, second)
+	}
+	if reflect.DeepEqual(first, third) {
+		t.Error("different seeds gave the same values")
+	}
+	if CurrentSeed() != 43 {
+		t.Errorf("CurrentSeed() = %d, want 43", CurrentSeed())
+	}
+}
+
+func TestFor(t *testing.T) {
+	draw := func(source *Source) []int {
+		values := []int{}
+		for i := 0; i < 20; i++ {
+			values = append(values, source.IntN(1000))
+		}
+		return values
+	}
+
+	Seed(42)
+	first := draw(For(100))
+	// draws from the shared source or other items in between change nothing
+	IntN(1000)
+	draw(For(101))
+	second := draw(For(100))
+	other := draw(For(101))
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("same seed and key gave %v then %v", first, second)
+	}
+	if reflect.DeepEqual(first, other) {
+		t.Error("different keys gave the same values")
+	}
+
+	Seed(43)
+	if reflect.DeepEqual(first, draw(For(100))) {
+		t.Error("different seeds gave the same values")
+	}
+}
@@ -0,0 +1,56 @@
+package spells
+
+import (
+	"fmt"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/dbc"
+)
+
+// SpellDbc builds the client Spell.dbc patch. Scaled spells are copied from the row of the
+// original spell so the tooltip, icon and visuals match, then the scaled values are written over it.
+type SpellDbc struct {
+	*dbc.Patch
+}
+
+// OpenSpellDbc loads the client Spell.dbc the scaled spells are added to
+func OpenSpellDbc(path string) (*SpellDbc, error) {
+	patch, err := dbc.OpenPatch(path, dbc.SpellSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SpellDbc{patch}, nil
+}
+
+func NewSpellDbc(file *dbc.File) *SpellDbc {
+	return &SpellDbc{dbc.NewPatch(file)}
+}
+
+// Add writes a scaled spell under newId. The same spell can be added more than once when several items
+// share it, the last values win like the spell_dbc UPDATE. A newId that is already in the client
+// Spell.dbc is an error, writing over it would change a spell the client already has.
+func (d *SpellDbc) Add(spell Spell, newId int) error {
+	source, found := d.Existing(uint32(spell.ID))
+	if !found {
+		return fmt.Errorf("spell %v (%v) is not in Spell.dbc to copy from", spell.Name, spell.ID)
+	}
+
+	record, err := d.Create(uint32(newId))
+	if err != nil {
+		return fmt.Errorf("scaled spell %v (%v): %w", spell.Name, spell.ID, err)
+	}
+
+	record.CopyFrom(source)
+	record.SetUint32("ID", uint32(newId))
+	for _, v := range spell.scaledValues() {
+		record.SetInt32(v.Column, int32(v.Value))
+	}
+	record.SetString("Name_Lang_enUS", spell.Name)
+	record.SetString("Description_Lang_enUS", spell.Description)
+	record.SetString("AuraDescription_Lang_enUS", spell.AuraDescription)
+	for _, column := range texts.Columns(spell.ID) {
+		record.SetString(column, texts[spell.ID][column])
+	}
+
+	return nil
+}
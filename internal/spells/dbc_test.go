@@ -0,0 +1,129 @@
+package spells
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/dbc"
+)
+
+func testSpellDbc() *SpellDbc {
+	file := dbc.New(dbc.SpellSchema)
+
+	fireball := file.Add()
+	fireball.SetUint32("ID", 133)
+	fireball.SetUint32("SpellIconID", 185)
+	fireball.SetInt32("EffectBasePoints_1", 13)
+	fireball.SetString("Name_Lang_enUS", "Fireball")
+
+	taken := file.Add()
+	taken.SetUint32("ID", uint32(ScaledSpellId(116, 4)))
+
+	return NewSpellDbc(file)
+}
+
+func TestSpellDbcAdd(t *testing.T) {
+	spell := Spell{DbSpell: mysql.DbSpell{
+		ID:                133,
+		Name:              "Fireball",
+		Description:       "Hurls a fiery ball that causes $s1 Fire damage.",
+		EffectBasePoints1: 512,
+		EffectDieSides1:   24,
+	}}
+
+	tests := []struct {
+		name    string
+		spell   Spell
+		newId   int
+		wantErr bool
+	}{
+		{"new scaled spell", spell, ScaledSpellId(133, 4), false},
+		{"same scaled spell again", spell, ScaledSpellId(133, 4), false},
+		{"collides with existing row", Spell{DbSpell: mysql.DbSpell{ID: 116}}, ScaledSpellId(116, 4), true},
+		{"source spell missing", Spell{DbSpell: mysql.DbSpell{ID: 999}}, ScaledSpellId(999, 4), true},
+	}
+
+	spellDbc := testSpellDbc()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := spellDbc.Add(tt.spell, tt.newId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Add() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if spellDbc.Count() != 1 {
+		t.Errorf("Count() = %d, want 1", spellDbc.Count())
+	}
+
+	out := new(bytes.Buffer)
+	if err := spellDbc.File.Write(out); err != nil {
+		t.Fatal(err)
+	}
+	read, err := dbc.Read(out, dbc.SpellSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := read.Find(31000133)
+	if record == nil {
+		t.Fatal("scaled spell not written")
+	}
+	if record.Int32("EffectBasePoints_1") != 512 || record.Int32("EffectDieSides_1") != 24 {
+		t.Errorf("scaled values not written: %d %d", record.Int32("EffectBasePoints_1"), record.Int32("EffectDieSides_1"))
+	}
+	if record.Uint32("SpellIconID") != 185 {
+		t.Errorf("SpellIconID = %d, want the original 185", record.Uint32("SpellIconID"))
+	}
+	if record.String("Description_Lang_enUS") != spell.Description {
+		t.Errorf("Description = %q", record.String("Description_Lang_enUS"))
+	}
+}
+
+func TestLoadTexts(t *testing.T) {
+	// a french and a german client each have their own translation of the spell
+	write := func(code, name, description string) string {
+		file := dbc.New(dbc.SpellSchema)
+		record := file.Add()
+		record.SetUint32("ID", 133)
+		record.SetString("Name_Lang_enUS", "Fireball")
+		record.SetString("Name_Lang_"+code, name)
+		record.SetString("Description_Lang_"+code, description)
+		path := t.TempDir() + "/Spell.dbc"
+		if err := file.WriteFile(path); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	texts, err := LoadTexts(write("frFR", "Boule de feu", "Inflige $s1 points de dégâts de Feu."), write("deDE", "Feuerball", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	columns := texts.Columns(133)
+	if strings.Join(columns, ",") != "Description_Lang_frFR,Name_Lang_deDE,Name_Lang_frFR" {
+		t.Fatalf("unexpected columns %v", columns)
+	}
+
+	UseTexts(texts)
+	defer UseTexts(nil)
+
+	spell := Spell{DbSpell: mysql.DbSpell{ID: 133, Name: "Fireball"}}
+	statements := SpellStatements(spell, 4)
+	if len(statements) != 3 || !strings.Contains(statements[2], "Name_Lang_frFR = 'Boule de feu'") || !strings.Contains(statements[2], fmt.Sprintf("WHERE ID = %d;", ScaledSpellId(133, 4))) {
+		t.Errorf("expected the translations written to the scaled spell: %v", statements)
+	}
+
+	spellDbc := testSpellDbc()
+	if err := spellDbc.Add(spell, ScaledSpellId(133, 4)); err != nil {
+		t.Fatal(err)
+	}
+	record, _ := spellDbc.Create(uint32(ScaledSpellId(133, 4)))
+	if name := record.String("Name_Lang_deDE"); name != "Feuerball" {
+		t.Errorf("expected the german name in the Spell.dbc patch, got %q", name)
+	}
+}
@@ -0,0 +1,82 @@
+package spells
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlrow"
+	"github.com/araxiaonline/endgame-item-generator/internal/dbc"
+)
+
+// Texts are translated spell names and descriptions by spell id and spell_dbc column
+type Texts map[int]map[string]string
+
+// the localized string fields of spell_dbc
+var textFields = []string{"Name", "NameSubtext", "Description", "AuraDescription"}
+
+// LoadTexts reads the translations out of client Spell.dbc files. A localized client fills the columns of
+// its own locale so the files of several clients add up. enUS is left out, that comes from spell_dbc.
+func LoadTexts(paths ...string) (Texts, error) {
+	texts := Texts{}
+	for _, path := range paths {
+		file, err := dbc.ReadFile(path, dbc.SpellSchema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read spell translations from %s: %w", path, err)
+		}
+		for _, record := range file.Records {
+			for _, field := range textFields {
+				for _, code := range dbc.Locales {
+					if code == "enUS" || code == "Unk" {
+						continue
+					}
+					column := field + "_Lang_" + code
+					if text := record.String(column); text != "" {
+						texts.set(int(record.ID()), column, text)
+					}
+				}
+			}
+		}
+	}
+	return texts, nil
+}
+
+func (t Texts) set(id int, column, text string) {
+	if t[id] == nil {
+		t[id] = map[string]string{}
+	}
+	t[id][column] = text
+}
+
+// Columns are the translated columns of spell id in a fixed order
+func (t Texts) Columns(id int) []string {
+	columns := make([]string, 0, len(t[id]))
+	for column := range t[id] {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+var texts Texts
+
+// UseTexts makes the scaled spells carry the translations of the spell they were scaled from
+func UseTexts(t Texts) {
+	texts = t
+}
+
+// textStatement writes the translations of spell over its scaled copy, empty when there are none
+func textStatement(spell Spell, newId int) string {
+	columns := texts.Columns(spell.ID)
+	if len(columns) == 0 {
+		return ""
+	}
+	sets := make([]string, len(columns))
+	for i, column := range columns {
+		sets[i] = fmt.Sprintf("%s = %s", column, sqlrow.Quote(texts[spell.ID][column]))
+	}
+	return fmt.Sprintf(`
+	UPDATE acore_world.spell_dbc
+	SET %s
+	WHERE ID = %v;`, strings.Join(sets, ", "), newId)
+}
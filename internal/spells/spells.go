@@ -2,12 +2,16 @@ package spells
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"math"
+	"strconv"
 	"strings"
 
 	"github.com/araxiaonline/endgame-item-generator/internal/config"
 	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/db/sqlrow"
+	"github.com/araxiaonline/endgame-item-generator/internal/ids"
+	"github.com/araxiaonline/endgame-item-generator/internal/logging"
 	"github.com/thoas/go-funk"
 )
 
@@ -100,6 +104,7 @@ type Spell struct {
 	mysql.DbSpell
 	Scaled        bool
 	ItemSpellSlot int
+	ScaledId      int // set by AllocateScaledId, 0 uses ScaledSpellId
 }
 
 func calcMaxValue(base int, sides int) int {
@@ -228,7 +233,7 @@ func (s Spell) ConvertToStats() ([]ConvItemStat, error) {
 	effects := s.GetAuraEffects()
 
 	if s.ID == 9397 {
-		log.Printf("Spell: %v AuraEffect1: %v AuraEffect2: %v AuraEffect3: %v", s.Name, s.EffectAura1, s.EffectAura2, s.EffectAura3)
+		s.logger("convert").Debug("aura effects", "name", s.Name, "aura1", s.EffectAura1, "aura2", s.EffectAura2, "aura3", s.EffectAura3)
 	}
 
 	var seen []int
@@ -358,7 +363,7 @@ func parseStatDesc(desc string) int {
 }
 
 // Scales a spell effect, means creating a new spell with the same effect but scaled to a new item level, then passing
-// back the new spellId, In order to be predictable I will use 30000000 for rare, 31000000 for epic, 32000000 for legendary
+// back the new spellId, the id comes from ScaledSpellId so it stays in the range of the current product line
 // An example of this might on hit do $s1 nature damage over $d seconds.  We would just scale the $s1 value
 // based on the formula below. This assumes that Blizzard has already balanced the spell bonus against the
 // stats on the item level and quality.  This is a big assumption as the stats are not penalized
@@ -372,14 +377,6 @@ func (s *Spell) ScaleSpell(fromItemLevel int, itemLevel int, itemQuality int) (i
 		5: 1.40,
 	}
 
-	idBump := 30000000
-	if itemQuality == 4 {
-		idBump = 31000000
-	}
-	if itemQuality == 5 {
-		idBump = 32000000
-	}
-
 	// direct damage types
 	dd := [...]int{2, 9, 10}
 
@@ -429,7 +426,7 @@ func (s *Spell) ScaleSpell(fromItemLevel int, itemLevel int, itemQuality int) (i
 		return 0, fmt.Errorf("did not qualify to be scaled in ScaleSpell %v (%v)", s.Name, s.ID)
 	}
 	s.Scaled = true
-	return idBump + s.ID, nil
+	return ScaledSpellId(s.ID, itemQuality), nil
 }
 
 // ForceScaleSpell is an enhanced version of ScaleSpell that scales ANY spell regardless of effect type.
@@ -759,24 +756,123 @@ func (s *Spell) ForceScaleSpell(fromItemLevel int, toItemLevel int, itemQuality
 		newValueWithPlus := "+" + newValueStr
 		if strings.Contains(s.Name, oldValueWithPlus) {
 			s.Name = strings.Replace(s.Name, oldValueWithPlus, newValueWithPlus, -1)
-			log.Printf("Updated spell name from '%s' to '%s' (replaced %s with %s)",
-				originalName, s.Name, oldValueWithPlus, newValueWithPlus)
+			s.logger("scale").Debug("updated spell name", "from", originalName, "to", s.Name)
 		}
 	}
 
+	s.logger("scale").Debug("scaled spell", "from", fromItemLevel, "itemLevel", toItemLevel, "quality", itemQuality,
+		"basePoints1", s.EffectBasePoints1, "basePoints2", s.EffectBasePoints2, "basePoints3", s.EffectBasePoints3)
 	s.Scaled = true
 	return nil
 }
 
-func SpellToSql(spell Spell, quality int) string {
+// logger tags the records of stage with the spell id
+func (s *Spell) logger(stage string) *slog.Logger {
+	return slog.With(logging.Spell, s.ID, logging.Stage, stage)
+}
+
+// ScaledSpellId is the id a scaled copy of a spell is written under, taken from the range the current
+// product line uses for the quality (see ids.Use)
+func ScaledSpellId(id int, quality int) int {
+	return ids.Current().Spell(quality).ID(id)
+}
+
+var idMap *ids.Map
+
+// UseIdMap gives every scaled spell an id per source spell and effect values from m, without one
+// all copies of a spell at a quality share the ScaledSpellId row
+func UseIdMap(m *ids.Map) {
+	idMap = m
+}
+
+// scaledValue is a spell_dbc column scaling can change and the spell's value for it
+type scaledValue struct {
+	Column string
+	Value  int
+}
+
+// scaledValues are the effect values ForceScaleSpell changes, in the order they are written to the
+// database, the dbc and the variant key
+func (s Spell) scaledValues() []scaledValue {
+	return []scaledValue{
+		{"EffectBasePoints_1", s.EffectBasePoints1},
+		{"EffectBasePoints_2", s.EffectBasePoints2},
+		{"EffectBasePoints_3", s.EffectBasePoints3},
+		{"EffectDieSides_1", s.EffectDieSides1},
+		{"EffectDieSides_2", s.EffectDieSides2},
+		{"EffectDieSides_3", s.EffectDieSides3},
+	}
+}
+
+// variant is what makes two scaled copies of a spell different, the values SpellStatements writes
+func (s Spell) variant() string {
+	values := []string{}
+	for _, v := range s.scaledValues() {
+		values = append(values, strconv.Itoa(v.Value))
+	}
+	return strings.Join(values, ",")
+}
 
-	entryBump := 30000000
-	if quality == 4 {
-		entryBump = 31000000
+// AllocateScaledId picks the id the scaled spell is written under once its values are final. Copies with the
+// same values share an id, the first values seen for a spell get ScaledSpellId and later different values get
+// an id from the product line's variant range.
+func (s *Spell) AllocateScaledId(quality int) error {
+	if idMap == nil {
+		s.ScaledId = ScaledSpellId(s.ID, quality)
+		return nil
 	}
-	if quality == 5 {
-		entryBump = 32000000
+
+	line := ids.Current()
+	id, err := idMap.Allocate(line.Spell(quality), line.SpellVariants(), s.ID, s.variant())
+	if err != nil {
+		return fmt.Errorf("failed to allocate an id for spell %v (%v): %w", s.Name, s.ID, err)
+	}
+	s.ScaledId = id
+	return nil
+}
+
+// WriteId is the id the scaled spell is written under
+func (s Spell) WriteId(quality int) int {
+	if s.ScaledId != 0 {
+		return s.ScaledId
 	}
+	return ScaledSpellId(s.ID, quality)
+}
+
+// SpellRow is the scaled spell as a complete spell_dbc row, the source row with the scaled id and values on top
+func SpellRow(db sqlrow.Querier, spell Spell, quality int) (sqlrow.Row, error) {
+	row, found, err := sqlrow.Read(db, "spell_dbc", "ID", spell.ID)
+	if err != nil {
+		return sqlrow.Row{}, err
+	}
+	if !found {
+		return sqlrow.Row{}, fmt.Errorf("source spell %d (%s) is not in spell_dbc", spell.ID, spell.Name)
+	}
+
+	if err := row.Set("ID", spell.WriteId(quality)); err != nil {
+		return sqlrow.Row{}, err
+	}
+	for _, v := range spell.scaledValues() {
+		if err := row.Set(v.Column, v.Value); err != nil {
+			return sqlrow.Row{}, err
+		}
+	}
+	for _, column := range texts.Columns(spell.ID) {
+		if err := row.Set(column, texts[spell.ID][column]); err != nil {
+			return sqlrow.Row{}, err
+		}
+	}
+	return row, nil
+}
+
+func SpellToSql(spell Spell, quality int) string {
+	return fmt.Sprintf("\n %s \n", strings.Join(SpellStatements(spell, quality), " \n "))
+}
+
+// SpellStatements are the copy of the spell to its scaled id and the update of the scaled values
+func SpellStatements(spell Spell, quality int) []string {
+
+	newId := spell.WriteId(quality)
 
 	insert := fmt.Sprintf(`
 	INSERT IGNORE INTO acore_world.spell_dbc (
@@ -816,43 +912,7 @@ func SpellToSql(spell Spell, quality int) string {
 		RequiredTotemCategoryID_2, RequiredAreasID, SchoolMask, RuneCostID, SpellMissileID, PowerDisplayID, EffectBonusMultiplier_1, EffectBonusMultiplier_2,
 		EffectBonusMultiplier_3, SpellDescriptionVariableID, SpellDifficultyID
 	) SELECT 
-	ID + %v, Category, DispelType, Mechanic, Attributes, AttributesEx, AttributesEx2, AttributesEx3, AttributesEx4,
-	AttributesEx5, AttributesEx6, AttributesEx7, ShapeshiftMask, unk_320_2, ShapeshiftExclude, unk_320_3, Targets,
-	TargetCreatureType, RequiresSpellFocus, FacingCasterFlags, CasterAuraState, TargetAuraState, ExcludeCasterAuraState,
-	ExcludeTargetAuraState, CasterAuraSpell, TargetAuraSpell, ExcludeCasterAuraSpell, ExcludeTargetAuraSpell, CastingTimeIndex,
-	RecoveryTime, CategoryRecoveryTime, InterruptFlags, AuraInterruptFlags, ChannelInterruptFlags, ProcTypeMask, ProcChance,
-	ProcCharges, MaxLevel, BaseLevel, SpellLevel, DurationIndex, PowerType, ManaCost, ManaCostPerLevel, ManaPerSecond,
-	ManaPerSecondPerLevel, RangeIndex, Speed, ModalNextSpell, CumulativeAura, Totem_1, Totem_2, Reagent_1, Reagent_2, Reagent_3,
-	Reagent_4, Reagent_5, Reagent_6, Reagent_7, Reagent_8, ReagentCount_1, ReagentCount_2, ReagentCount_3, ReagentCount_4,
-	ReagentCount_5, ReagentCount_6, ReagentCount_7, ReagentCount_8, EquippedItemClass, EquippedItemSubclass, EquippedItemInvTypes,
-	Effect_1, Effect_2, Effect_3, EffectDieSides_1, EffectDieSides_2, EffectDieSides_3, EffectRealPointsPerLevel_1,
-	EffectRealPointsPerLevel_2, EffectRealPointsPerLevel_3, EffectBasePoints_1, EffectBasePoints_2, EffectBasePoints_3,
-	EffectMechanic_1, EffectMechanic_2, EffectMechanic_3, ImplicitTargetA_1, ImplicitTargetA_2, ImplicitTargetA_3, ImplicitTargetB_1,
-	ImplicitTargetB_2, ImplicitTargetB_3, EffectRadiusIndex_1, EffectRadiusIndex_2, EffectRadiusIndex_3, EffectAura_1,
-	EffectAura_2, EffectAura_3, EffectAuraPeriod_1, EffectAuraPeriod_2, EffectAuraPeriod_3, EffectMultipleValue_1, EffectMultipleValue_2,
-	EffectMultipleValue_3, EffectChainTargets_1, EffectChainTargets_2, EffectChainTargets_3, EffectItemType_1, EffectItemType_2,
-	EffectItemType_3, EffectMiscValue_1, EffectMiscValue_2, EffectMiscValue_3, EffectMiscValueB_1, EffectMiscValueB_2, EffectMiscValueB_3,
-	EffectTriggerSpell_1, EffectTriggerSpell_2, EffectTriggerSpell_3, EffectPointsPerCombo_1, EffectPointsPerCombo_2, EffectPointsPerCombo_3,
-	EffectSpellClassMaskA_1, EffectSpellClassMaskA_2, EffectSpellClassMaskA_3, EffectSpellClassMaskB_1, EffectSpellClassMaskB_2,
-	EffectSpellClassMaskB_3, EffectSpellClassMaskC_1, EffectSpellClassMaskC_2, EffectSpellClassMaskC_3, SpellVisualID_1, SpellVisualID_2,
-	SpellIconID, ActiveIconID, SpellPriority, Name_Lang_enUS, Name_Lang_enGB, Name_Lang_koKR, Name_Lang_frFR, Name_Lang_deDE,
-	Name_Lang_enCN, Name_Lang_zhCN, Name_Lang_enTW, Name_Lang_zhTW, Name_Lang_esES, Name_Lang_esMX, Name_Lang_ruRU, Name_Lang_ptPT,
-	Name_Lang_ptBR, Name_Lang_itIT, Name_Lang_Unk, Name_Lang_Mask, NameSubtext_Lang_enUS, NameSubtext_Lang_enGB, NameSubtext_Lang_koKR,
-	NameSubtext_Lang_frFR, NameSubtext_Lang_deDE, NameSubtext_Lang_enCN, NameSubtext_Lang_zhCN, NameSubtext_Lang_enTW, NameSubtext_Lang_zhTW,
-	NameSubtext_Lang_esES, NameSubtext_Lang_esMX, NameSubtext_Lang_ruRU, NameSubtext_Lang_ptPT, NameSubtext_Lang_ptBR, NameSubtext_Lang_itIT,
-	NameSubtext_Lang_Unk, NameSubtext_Lang_Mask, Description_Lang_enUS, Description_Lang_enGB, Description_Lang_koKR, Description_Lang_frFR,
-	Description_Lang_deDE, Description_Lang_enCN, Description_Lang_zhCN, Description_Lang_enTW, Description_Lang_zhTW, Description_Lang_esES,
-	Description_Lang_esMX, Description_Lang_ruRU, Description_Lang_ptPT, Description_Lang_ptBR, Description_Lang_itIT, Description_Lang_Unk,
-	Description_Lang_Mask, AuraDescription_Lang_enUS, AuraDescription_Lang_enGB, AuraDescription_Lang_koKR, AuraDescription_Lang_frFR,
-	AuraDescription_Lang_deDE, AuraDescription_Lang_enCN, AuraDescription_Lang_zhCN, AuraDescription_Lang_enTW, AuraDescription_Lang_zhTW,
-	AuraDescription_Lang_esES, AuraDescription_Lang_esMX, AuraDescription_Lang_ruRU, AuraDescription_Lang_ptPT, AuraDescription_Lang_ptBR,
-	AuraDescription_Lang_itIT, AuraDescription_Lang_Unk, AuraDescription_Lang_Mask, ManaCostPct, StartRecoveryCategory, StartRecoveryTime,
-	MaxTargetLevel, SpellClassSet, SpellClassMask_1, SpellClassMask_2, SpellClassMask_3, MaxTargets, DefenseType, PreventionType, StanceBarOrder,
-	EffectChainAmplitude_1, EffectChainAmplitude_2, EffectChainAmplitude_3, MinFactionID, MinReputation, RequiredAuraVision, RequiredTotemCategoryID_1,
-	RequiredTotemCategoryID_2, RequiredAreasID, SchoolMask, RuneCostID, SpellMissileID, PowerDisplayID, EffectBonusMultiplier_1, EffectBonusMultiplier_2,
-	EffectBonusMultiplier_3, SpellDescriptionVariableID, SpellDifficultyID
-	) SELECT 
-	ID + %v, Category, DispelType, Mechanic, Attributes, AttributesEx, AttributesEx2, AttributesEx3, AttributesEx4,
+	%v, Category, DispelType, Mechanic, Attributes, AttributesEx, AttributesEx2, AttributesEx3, AttributesEx4,
 	AttributesEx5, AttributesEx6, AttributesEx7, ShapeshiftMask, unk_320_2, ShapeshiftExclude, unk_320_3, Targets,
 	TargetCreatureType, RequiresSpellFocus, FacingCasterFlags, CasterAuraState, TargetAuraState, ExcludeCasterAuraState,
 	ExcludeTargetAuraState, CasterAuraSpell, TargetAuraSpell, ExcludeCasterAuraSpell, ExcludeTargetAuraSpell, CastingTimeIndex,
@@ -887,12 +947,19 @@ func SpellToSql(spell Spell, quality int) string {
 	EffectChainAmplitude_1, EffectChainAmplitude_2, EffectChainAmplitude_3, MinFactionID, MinReputation, RequiredAuraVision, RequiredTotemCategoryID_1,
 	RequiredTotemCategoryID_2, RequiredAreasID, SchoolMask, RuneCostID, SpellMissileID, PowerDisplayID, EffectBonusMultiplier_1, EffectBonusMultiplier_2,
 	EffectBonusMultiplier_3, SpellDescriptionVariableID, SpellDifficultyID from acore_world.spell_dbc as src
-	WHERE src.ID = %v ON DUPLICATE KEY UPDATE ID = src.ID + %v;`, entryBump, spell.ID, entryBump)
+	WHERE src.ID = %v ON DUPLICATE KEY UPDATE ID = %v;`, newId, spell.ID, newId)
 
+	set := []string{}
+	for _, v := range spell.scaledValues() {
+		set = append(set, fmt.Sprintf("%s = %v", v.Column, v.Value))
+	}
 	update := fmt.Sprintf(`
 	UPDATE acore_world.spell_dbc
-	SET EffectBasePoints_1 = %v, EffectBasePoints_2 = %v
-	WHERE ID = %v;`, spell.EffectBasePoints1, spell.EffectBasePoints2, entryBump+spell.ID)
+	SET %s
+	WHERE ID = %v;`, strings.Join(set, ", "), newId)
 
-	return fmt.Sprintf("\n %s \n %s \n", insert, update)
+	if translations := textStatement(spell, newId); translations != "" {
+		return []string{insert, update, translations}
+	}
+	return []string{insert, update}
 }
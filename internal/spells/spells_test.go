@@ -1,9 +1,13 @@
 package spells
 
 import (
+	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/araxiaonline/endgame-item-generator/internal/db/mysql"
+	"github.com/araxiaonline/endgame-item-generator/internal/ids"
 )
 
 func TestCanBeConverted(t *testing.T) {
@@ -58,3 +62,44 @@ func TestCanBeConverted(t *testing.T) {
 		})
 	}
 }
+
+func TestAllocateScaledId(t *testing.T) {
+	m, err := ids.LoadMap(filepath.Join(t.TempDir(), "spell-ids.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	UseIdMap(m)
+	defer UseIdMap(nil)
+
+	proc := func(points int, dieSides int) Spell {
+		return Spell{DbSpell: mysql.DbSpell{ID: 21919, EffectBasePoints1: points, EffectDieSides3: dieSides}}
+	}
+	tests := []struct {
+		name     string
+		spell    Spell
+		expected int
+	}{
+		{"first values", proc(120, 1), 31021919},
+		{"same values share the row", proc(120, 1), 31021919},
+		{"other values get their own row", proc(250, 1), 34000000},
+		{"other die sides get their own row", proc(120, 7), 34000001},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.spell.AllocateScaledId(4); err != nil {
+				t.Fatal(err)
+			}
+			if id := test.spell.WriteId(4); id != test.expected {
+				t.Errorf("expected %d, got %d", test.expected, id)
+			}
+			statements := SpellStatements(test.spell, 4)
+			if !strings.Contains(statements[1], fmt.Sprintf("WHERE ID = %d;", test.expected)) {
+				t.Errorf("expected the update to write %d: %s", test.expected, statements[1])
+			}
+			dieSides := fmt.Sprintf("EffectDieSides_3 = %d", test.spell.EffectDieSides3)
+			if !strings.Contains(statements[1], dieSides) {
+				t.Errorf("expected the update to set %s: %s", dieSides, statements[1])
+			}
+		})
+	}
+}